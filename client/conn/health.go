@@ -16,7 +16,8 @@ type HealthChecker struct {
 	interval    time.Duration
 	mutex       sync.RWMutex
 	isRunning   bool
-	onReconnect func() // 可选的重连回调
+	unhealthy   bool   // 上一次检查时连接是否处于异常状态
+	onReconnect func() // 可选的重连回调，重连成功或连接自行恢复后调用
 	debug       bool
 }
 
@@ -106,7 +107,37 @@ func (h *HealthChecker) checkHealth() {
 		if h.debug {
 			log.Printf("[INFO] HealthChecker: 检测到连接异常状态: %v, 正在尝试重连", state)
 		}
+		h.setUnhealthy(true)
 		h.reconnect()
+		return
+	}
+
+	// gRPC 连接也可能在两次检查之间自行恢复
+	if state == connectivity.Ready && h.setUnhealthy(false) {
+		if h.debug {
+			log.Printf("[INFO] HealthChecker: 连接已恢复")
+		}
+		h.notifyReconnect()
+	}
+}
+
+// setUnhealthy 更新连接异常标记，返回更新前的值
+func (h *HealthChecker) setUnhealthy(unhealthy bool) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	previous := h.unhealthy
+	h.unhealthy = unhealthy
+	return previous
+}
+
+// notifyReconnect 调用重连回调
+func (h *HealthChecker) notifyReconnect() {
+	h.mutex.RLock()
+	onReconnect := h.onReconnect
+	h.mutex.RUnlock()
+
+	if onReconnect != nil {
+		onReconnect()
 	}
 }
 
@@ -135,12 +166,6 @@ func (h *HealthChecker) reconnect() {
 		log.Printf("[INFO] HealthChecker: 重连成功")
 	}
 
-	// 调用重连回调
-	h.mutex.RLock()
-	onReconnect := h.onReconnect
-	h.mutex.RUnlock()
-
-	if onReconnect != nil {
-		onReconnect()
-	}
+	h.setUnhealthy(false)
+	h.notifyReconnect()
 }
@@ -8,19 +8,28 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 // Manager 管理gRPC连接的生命周期
+// Manager 同时实现了 grpc.ClientConnInterface，通过它创建的服务存根在重连后会自动使用新连接。
 type Manager struct {
 	conn            *grpc.ClientConn
+	connLock        sync.RWMutex // 保护 conn 的读写，重连期间不阻塞正在进行的调用
 	target          string
 	connectionMutex sync.Mutex
 	healthChecker   *HealthChecker
 	healthCheckLock sync.Mutex
+	onReconnect     func() // 连接恢复后的回调
 	debug           bool
 	tlsConfig       TLSConfig
+
+	unaryInterceptors  []grpc.UnaryClientInterceptor  // 建立连接时安装的一元拦截器链
+	streamInterceptors []grpc.StreamClientInterceptor // 建立连接时安装的流式拦截器链
+	dialOptions        []grpc.DialOption              // 额外的拨号选项
 }
 
 // ManagerOption 定义连接管理器配置选项
@@ -42,6 +51,27 @@ func WithTLS(config TLSConfig) ManagerOption {
 	}
 }
 
+// WithUnaryInterceptors 配置连接使用的一元拦截器，按传入顺序由外到内执行
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) ManagerOption {
+	return func(m *Manager) {
+		m.unaryInterceptors = append(m.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors 配置连接使用的流式拦截器，按传入顺序由外到内执行
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) ManagerOption {
+	return func(m *Manager) {
+		m.streamInterceptors = append(m.streamInterceptors, interceptors...)
+	}
+}
+
+// WithDialOptions 配置额外的 gRPC 拨号选项，在内置选项之后应用
+func WithDialOptions(opts ...grpc.DialOption) ManagerOption {
+	return func(m *Manager) {
+		m.dialOptions = append(m.dialOptions, opts...)
+	}
+}
+
 // NewManager 创建新的连接管理器
 func NewManager(target string, timeout time.Duration, debug bool, opts ...ManagerOption) (*Manager, error) {
 	if target == "" {
@@ -96,6 +126,17 @@ func (m *Manager) connect(ctx context.Context) error {
 		}
 	}
 
+	// 安装拦截器链，重连时同样生效
+	if len(m.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(m.unaryInterceptors...))
+	}
+	if len(m.streamInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(m.streamInterceptors...))
+	}
+
+	// 用户提供的拨号选项最后应用，可以覆盖前面的默认设置
+	opts = append(opts, m.dialOptions...)
+
 	// 使用最新的gRPC连接语法
 	conn, err := grpc.NewClient(m.target, opts...)
 	if err != nil {
@@ -142,7 +183,9 @@ func (m *Manager) connect(ctx context.Context) error {
 		}
 	}
 
+	m.connLock.Lock()
 	m.conn = conn
+	m.connLock.Unlock()
 	return nil
 }
 
@@ -154,8 +197,8 @@ func (m *Manager) Reconnect(ctx context.Context, target string) error {
 	}
 
 	// 关闭旧连接
-	if m.conn != nil {
-		err := m.conn.Close()
+	if conn := m.GetConn(); conn != nil {
+		err := conn.Close()
 		if err != nil {
 			return err
 		}
@@ -165,16 +208,49 @@ func (m *Manager) Reconnect(ctx context.Context, target string) error {
 }
 
 // GetConn 获取gRPC连接
+// 重连后会返回新的连接，需要长期持有连接时应直接使用 Manager 作为 grpc.ClientConnInterface。
 func (m *Manager) GetConn() *grpc.ClientConn {
+	m.connLock.RLock()
+	defer m.connLock.RUnlock()
 	return m.conn
 }
 
 // GetState 获取连接状态
 func (m *Manager) GetState() connectivity.State {
-	if m.conn == nil {
+	conn := m.GetConn()
+	if conn == nil {
 		return connectivity.Shutdown
 	}
-	return m.conn.GetState()
+	return conn.GetState()
+}
+
+// Invoke 实现 grpc.ClientConnInterface，使用当前连接发起一元调用
+func (m *Manager) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	conn := m.GetConn()
+	if conn == nil {
+		return status.Error(codes.Unavailable, "gRPC 连接已关闭")
+	}
+	return conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream 实现 grpc.ClientConnInterface，使用当前连接创建流
+func (m *Manager) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	conn := m.GetConn()
+	if conn == nil {
+		return nil, status.Error(codes.Unavailable, "gRPC 连接已关闭")
+	}
+	return conn.NewStream(ctx, desc, method, opts...)
+}
+
+// SetReconnectCallback 设置连接恢复后的回调函数，仅在启用健康检查时生效
+func (m *Manager) SetReconnectCallback(cb func()) {
+	m.healthCheckLock.Lock()
+	defer m.healthCheckLock.Unlock()
+
+	m.onReconnect = cb
+	if m.healthChecker != nil {
+		m.healthChecker.SetReconnectCallback(cb)
+	}
 }
 
 // Close 关闭连接
@@ -185,13 +261,16 @@ func (m *Manager) Close() error {
 	m.connectionMutex.Lock()
 	defer m.connectionMutex.Unlock()
 
-	if m.conn != nil {
+	m.connLock.Lock()
+	conn := m.conn
+	m.conn = nil
+	m.connLock.Unlock()
+
+	if conn != nil {
 		if m.debug {
-			log.Printf("[INFO] Manager.Close: 正在关闭 gRPC 连接: %s", m.conn.Target())
+			log.Printf("[INFO] Manager.Close: 正在关闭 gRPC 连接: %s", conn.Target())
 		}
-		err := m.conn.Close()
-		m.conn = nil
-		return err
+		return conn.Close()
 	}
 	return nil
 }
@@ -206,6 +285,9 @@ func (m *Manager) startHealthCheck(interval time.Duration) {
 	}
 
 	m.healthChecker = NewHealthChecker(m, interval, m.debug)
+	if m.onReconnect != nil {
+		m.healthChecker.SetReconnectCallback(m.onReconnect)
+	}
 	m.healthChecker.Start()
 }
 
@@ -6,19 +6,28 @@ import (
 	"time"
 
 	"github.com/iwen-conf/email_client/client/conn"
+	"github.com/iwen-conf/email_client/client/middleware"
+	"github.com/iwen-conf/email_client/client/outbox"
 	"github.com/iwen-conf/email_client/client/services"
 	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc"
 )
 
 // EmailClient 是一个高级客户端，封装了与邮件服务和配置服务的交互。
 type EmailClient struct {
-	connManager     *conn.Manager
-	emailService    *services.EmailServiceClient
-	configService   *services.ConfigServiceClient
-	healthService   *services.HealthServiceClient
-	requestTimeout  time.Duration
-	defaultPageSize int32
-	debug           bool
+	connManager        *conn.Manager
+	emailService       *services.EmailServiceClient
+	configService      *services.ConfigServiceClient
+	templateService    *services.TemplateServiceClient
+	suppressionService *services.SuppressionServiceClient
+	healthService      *services.HealthServiceClient
+	outbox             *outbox.Outbox
+	metrics            *middleware.ClientMetrics
+	circuitBreaker     *middleware.CircuitBreaker
+	rateLimiter        *middleware.RateLimiter
+	requestTimeout     time.Duration
+	defaultPageSize    int32
+	debug              bool
 }
 
 // NewEmailClient 创建一个新的 EmailClient 实例。
@@ -38,9 +47,19 @@ func NewEmailClient(grpcAddress string, requestTimeout time.Duration, defaultPag
 		log.Printf("[INFO] NewEmailClient: 正在尝试连接统一 gRPC 服务: %s", grpcAddress)
 	}
 
+	client := &EmailClient{
+		metrics:         middleware.NewClientMetrics(0),
+		requestTimeout:  requestTimeout,
+		defaultPageSize: defaultPageSize,
+		debug:           debug,
+	}
+
 	// 创建连接管理器选项
 	managerOpts := []conn.ManagerOption{
 		conn.WithHealthCheck(options.enableHealthCheck, options.healthCheckInterval),
+		conn.WithUnaryInterceptors(client.buildUnaryInterceptors(&options)...),
+		conn.WithStreamInterceptors(options.streamInterceptors...),
+		conn.WithDialOptions(options.dialOptions...),
 	}
 
 	// 添加TLS选项
@@ -58,23 +77,91 @@ func NewEmailClient(grpcAddress string, requestTimeout time.Duration, defaultPag
 	}
 
 	// 创建内部的服务客户端实例
-	emailService := services.NewEmailServiceClient(connManager.GetConn(), requestTimeout, defaultPageSize, debug)
-	configService := services.NewConfigServiceClient(connManager.GetConn(), requestTimeout, defaultPageSize, debug)
-	healthService := services.NewHealthServiceClient(connManager.GetConn(), requestTimeout, debug)
+	client.connManager = connManager
+	client.emailService = services.NewEmailServiceClient(connManager, requestTimeout, defaultPageSize, debug)
+	client.configService = services.NewConfigServiceClient(connManager, requestTimeout, defaultPageSize, debug)
+	client.templateService = services.NewTemplateServiceClient(connManager, requestTimeout, defaultPageSize, debug)
+	client.suppressionService = services.NewSuppressionServiceClient(connManager, requestTimeout, defaultPageSize, debug)
+	client.healthService = services.NewHealthServiceClient(connManager, requestTimeout, debug)
 
 	if debug {
-		log.Printf("[INFO] NewEmailClient: 成功创建所有服务客户端 (Email, Config, Health)")
+		log.Printf("[INFO] NewEmailClient: 成功创建所有服务客户端 (Email, Config, Template, Suppression, Health)")
 	}
 
-	return &EmailClient{
-		connManager:     connManager,
-		emailService:    emailService,
-		configService:   configService,
-		healthService:   healthService,
-		requestTimeout:  requestTimeout,
-		defaultPageSize: defaultPageSize,
-		debug:           debug,
-	}, nil
+	if options.suppressionFilter {
+		// 邮件服务和模板服务共用同一份缓存，通过抑制列表服务增删地址时同步更新缓存
+		cache := services.NewSuppressionCache(client.suppressionService, options.suppressionOptions...)
+		client.emailService.SetSuppressionFilter(cache)
+		client.templateService.SetSuppressionFilter(cache)
+		client.suppressionService.SetCache(cache)
+	}
+
+	if options.outboxDir != "" {
+		outboxOpts := append([]outbox.Option{outbox.WithDebug(debug), outbox.WithAutoDrain(outbox.DefaultRetryInterval)}, options.outboxOptions...)
+		client.outbox, err = outbox.Open(options.outboxDir, client.emailService.SendEmail, outboxOpts...)
+		if err != nil {
+			connManager.Close()
+			return nil, err
+		}
+
+		// 发件箱在后台按退避间隔重试发送；连接恢复后以及启动时立即发送一次，不必等待重试间隔
+		connManager.SetReconnectCallback(func() { go client.drainOutbox() })
+		if client.outbox.Depth() > 0 {
+			go client.drainOutbox()
+		}
+	}
+
+	return client, nil
+}
+
+// drainOutbox 发送发件箱中的邮件
+func (c *EmailClient) drainOutbox() {
+	sent, err := c.outbox.Drain(context.Background())
+	if c.debug {
+		log.Printf("[INFO] EmailClient: 已发送发件箱中的 %d 封邮件，剩余 %d 封", sent, c.outbox.Depth())
+	}
+	if err != nil && c.debug {
+		log.Printf("[WARN] EmailClient: 发件箱发送中断: %v", err)
+	}
+}
+
+// buildUnaryInterceptors 根据选项构建一元拦截器链
+// 执行顺序为：速率限制 -> 断路器 -> 重试 -> 指标收集 -> 用户自定义拦截器
+func (c *EmailClient) buildUnaryInterceptors(options *clientOptions) []grpc.UnaryClientInterceptor {
+	var interceptors []grpc.UnaryClientInterceptor
+
+	if options.enableRateLimiter {
+		c.rateLimiter = middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			RequestsPerSecond: options.requestsPerSecond,
+			MaxBurst:          options.maxBurst,
+			WaitTimeout:       options.rateLimiterWaitTimeout,
+		}, c.debug)
+		interceptors = append(interceptors, middleware.RateLimiterInterceptor(c.rateLimiter))
+		if c.debug {
+			log.Printf("[INFO] NewEmailClient: 启用速率限制 (%.2f requests/s)", options.requestsPerSecond)
+		}
+	}
+
+	if options.enableCircuitBreaker {
+		c.circuitBreaker = middleware.NewCircuitBreaker(middleware.CircuitBreakerConfig{
+			FailureThreshold:    options.failureThreshold,
+			ResetTimeout:        options.circuitResetTimeout,
+			HalfOpenMaxRequests: options.halfOpenMaxRequests,
+		}, c.debug)
+		interceptors = append(interceptors, middleware.CircuitBreakerInterceptor(c.circuitBreaker))
+		if c.debug {
+			log.Printf("[INFO] NewEmailClient: 启用断路器 (失败阈值: %d)", options.failureThreshold)
+		}
+	}
+
+	// 指标收集始终启用，MaxRetries 为 0（默认）时只收集指标不重试
+	interceptors = append(interceptors, middleware.WithRetryAndMetrics(c.debug, c.metrics, middleware.RetryConfig{
+		MaxRetries:  options.maxRetries,
+		RetryDelay:  options.retryDelay,
+		RetryPolicy: options.retryPolicy,
+	}))
+
+	return append(interceptors, options.unaryInterceptors...)
 }
 
 // Close 关闭 EmailClient 管理的共享 gRPC 连接。
@@ -82,6 +169,11 @@ func (c *EmailClient) Close() error {
 	if c.debug {
 		log.Printf("[INFO] EmailClient.Close: 正在关闭共享 gRPC 连接")
 	}
+	if c.outbox != nil {
+		if err := c.outbox.Close(); err != nil {
+			log.Printf("[ERROR] EmailClient.Close: 关闭发件箱失败: %v", err)
+		}
+	}
 	return c.connManager.Close()
 }
 
@@ -95,6 +187,30 @@ func (c *EmailClient) ConfigService() *services.ConfigServiceClient {
 	return c.configService
 }
 
+// TemplateService 返回封装好的 TemplateServiceClient 实例。
+func (c *EmailClient) TemplateService() *services.TemplateServiceClient {
+	return c.templateService
+}
+
+// SuppressionService 返回封装好的 SuppressionServiceClient 实例。
+func (c *EmailClient) SuppressionService() *services.SuppressionServiceClient {
+	return c.suppressionService
+}
+
+// BulkSender 创建使用该客户端邮件服务的批量发送器
+// 启用速率限制时，批量发送器会在每个分块发送前等待客户端速率限制器中的可用令牌。
+func (c *EmailClient) BulkSender(opts ...services.BulkOption) *services.BulkSender {
+	if c.rateLimiter != nil {
+		opts = append([]services.BulkOption{services.WithBulkRateLimiter(c.rateLimiter)}, opts...)
+	}
+	return services.NewBulkSender(c.emailService, opts...)
+}
+
+// Outbox 返回客户端的发件箱，未通过 WithOutbox 启用时返回 nil
+func (c *EmailClient) Outbox() *outbox.Outbox {
+	return c.outbox
+}
+
 // HealthService 返回健康检查服务的客户端实例
 func (c *EmailClient) HealthService() *services.HealthServiceClient {
 	return c.healthService
@@ -118,6 +234,12 @@ func (c *EmailClient) SetRequestTimeout(timeout time.Duration) {
 	if c.configService != nil {
 		c.configService.SetRequestTimeout(timeout)
 	}
+	if c.templateService != nil {
+		c.templateService.SetRequestTimeout(timeout)
+	}
+	if c.suppressionService != nil {
+		c.suppressionService.SetRequestTimeout(timeout)
+	}
 	if c.healthService != nil {
 		// 假设 HealthServiceClient 也有 SetRequestTimeout 方法
 		// c.healthService.SetRequestTimeout(timeout)
@@ -133,6 +255,27 @@ func (c *EmailClient) SetDefaultPageSize(size int32) {
 	if c.configService != nil {
 		c.configService.SetDefaultPageSize(size)
 	}
+	if c.templateService != nil {
+		c.templateService.SetDefaultPageSize(size)
+	}
+	if c.suppressionService != nil {
+		c.suppressionService.SetDefaultPageSize(size)
+	}
+}
+
+// Metrics 返回客户端请求指标的快照
+func (c *EmailClient) Metrics() middleware.ClientMetricsSnapshot {
+	return c.metrics.GetStats()
+}
+
+// CircuitBreaker 返回客户端使用的断路器，未启用时返回 nil
+func (c *EmailClient) CircuitBreaker() *middleware.CircuitBreaker {
+	return c.circuitBreaker
+}
+
+// RateLimiter 返回客户端使用的速率限制器，未启用时返回 nil
+func (c *EmailClient) RateLimiter() *middleware.RateLimiter {
+	return c.rateLimiter
 }
 
 // GetConnManager 返回底层的连接管理器
@@ -5,6 +5,9 @@ import (
 
 	"github.com/iwen-conf/email_client/client/conn"
 	"github.com/iwen-conf/email_client/client/middleware"
+	"github.com/iwen-conf/email_client/client/outbox"
+	"github.com/iwen-conf/email_client/client/services"
+	"google.golang.org/grpc"
 )
 
 // Option 定义客户端配置选项的函数类型
@@ -21,7 +24,7 @@ type clientOptions struct {
 	healthCheckInterval time.Duration // 健康检查间隔
 
 	// 重试相关选项
-	maxRetries  int                    // 最大重试次数
+	maxRetries  int                    // 最大重试次数，0 表示不重试
 	retryDelay  time.Duration          // 重试延迟
 	retryPolicy middleware.RetryPolicy // 重试策略
 
@@ -40,6 +43,19 @@ type clientOptions struct {
 	// TLS相关选项
 	enableTLS bool           // 是否启用TLS
 	tlsConfig conn.TLSConfig // TLS配置
+
+	// 自定义拦截器与拨号选项
+	unaryInterceptors  []grpc.UnaryClientInterceptor  // 用户自定义一元拦截器
+	streamInterceptors []grpc.StreamClientInterceptor // 用户自定义流式拦截器
+	dialOptions        []grpc.DialOption              // 用户自定义拨号选项
+
+	// 发件箱选项
+	outboxDir     string          // 发件箱目录，为空表示不启用
+	outboxOptions []outbox.Option // 发件箱配置
+
+	// 抑制列表过滤选项
+	suppressionFilter  bool                              // 是否在发送前过滤被抑制的收件人
+	suppressionOptions []services.SuppressionCacheOption // 抑制列表缓存配置
 }
 
 // 默认选项
@@ -51,7 +67,7 @@ var defaultOptions = clientOptions{
 	enableHealthCheck:   true,
 	healthCheckInterval: 30 * time.Second,
 
-	maxRetries:  3,
+	maxRetries:  0, // 重试需通过 WithRetryConfig 显式启用，避免不支持幂等键的服务端重复发送邮件
 	retryDelay:  500 * time.Millisecond,
 	retryPolicy: middleware.ExponentialBackoff,
 
@@ -101,7 +117,8 @@ var DefaultConnectionConfig = ConnectionConfig{
 	HealthCheckInterval: 30 * time.Second,
 }
 
-// WithRetryConfig 设置重试相关配置
+// WithRetryConfig 设置重试相关配置，默认不重试
+// 重试会重新发送 SendEmail 等非幂等请求，只有在服务端支持幂等键去重时才应启用。
 func WithRetryConfig(config RetryConfig) Option {
 	return func(opts *clientOptions) {
 		opts.maxRetries = config.MaxRetries
@@ -119,7 +136,7 @@ type RetryConfig struct {
 	RetryPolicy middleware.RetryPolicy // 重试策略函数
 }
 
-// DefaultRetryConfig 提供默认重试配置
+// DefaultRetryConfig 提供推荐的重试配置，可以通过 WithRetryConfig(DefaultRetryConfig) 启用
 var DefaultRetryConfig = RetryConfig{
 	MaxRetries:  3,
 	RetryDelay:  500 * time.Millisecond,
@@ -205,3 +222,51 @@ func DisableHealthCheck() Option {
 		opts.enableHealthCheck = false
 	}
 }
+
+// WithUnaryInterceptors 添加自定义一元拦截器
+// 自定义拦截器位于内置拦截器链（速率限制 -> 断路器 -> 重试 -> 指标收集）之后，
+// 按传入顺序执行，因此每次重试都会重新经过这些拦截器，适合注入认证信息、链路追踪和审计日志。
+// 多次调用时按调用顺序追加。
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(opts *clientOptions) {
+		opts.unaryInterceptors = append(opts.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors 添加自定义流式拦截器，按传入顺序执行，多次调用时按调用顺序追加
+// 内置中间件只作用于一元调用，流式调用只经过这里配置的拦截器。
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(opts *clientOptions) {
+		opts.streamInterceptors = append(opts.streamInterceptors, interceptors...)
+	}
+}
+
+// WithDialOptions 添加自定义 gRPC 拨号选项
+// 这些选项在内置的传输凭据和拦截器之后应用，并在重连时保留。
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(opts *clientOptions) {
+		opts.dialOptions = append(opts.dialOptions, dialOptions...)
+	}
+}
+
+// WithOutbox 启用基于本地文件的发件箱，dir 为发件箱目录
+// 通过 EmailClient.Outbox().Send 发送的邮件在服务端不可达时会被持久化，并在后台按退避间隔
+// （首次 outbox.DefaultRetryInterval，可通过 outbox.WithAutoDrain 修改）按入队顺序重新发送；
+// 启用健康检查时，连接恢复后会立即重新发送。
+func WithOutbox(dir string, outboxOpts ...outbox.Option) Option {
+	return func(opts *clientOptions) {
+		opts.outboxDir = dir
+		opts.outboxOptions = append(opts.outboxOptions, outboxOpts...)
+	}
+}
+
+// WithSuppressionFilter 启用发送前的抑制列表过滤
+// 客户端会在本地缓存服务端的抑制列表并定期刷新，EmailService 的 SendEmail、SendEmails、SendStream
+// 和 TemplateService 的 SendTemplated 在发送前移除被抑制的收件人，所有收件人都被抑制的邮件不会发送。
+// Send、Schedule、BulkSender 和发件箱都经由 SendEmail 或 SendEmails 发送，同样会被过滤。
+func WithSuppressionFilter(cacheOpts ...services.SuppressionCacheOption) Option {
+	return func(opts *clientOptions) {
+		opts.suppressionFilter = true
+		opts.suppressionOptions = append(opts.suppressionOptions, cacheOpts...)
+	}
+}
@@ -32,6 +32,12 @@ type (
 	// RateLimitExceededError 表示速率限制异常
 	RateLimitExceededError = middleware.RateLimitExceededError
 
+	// CircuitOpenError 表示断路器处于开路状态
+	CircuitOpenError = middleware.CircuitOpenError
+
+	// ClientMetricsSnapshot 提供客户端请求指标的快照
+	ClientMetricsSnapshot = middleware.ClientMetricsSnapshot
+
 	// TLSConfig 定义TLS配置参数
 	TLSConfig = conn.TLSConfig
 )
@@ -85,6 +91,21 @@ var (
 
 	// DisableHealthCheck 禁用健康检查
 	DisableHealthCheck = core.DisableHealthCheck
+
+	// WithUnaryInterceptors 添加自定义一元拦截器
+	WithUnaryInterceptors = core.WithUnaryInterceptors
+
+	// WithStreamInterceptors 添加自定义流式拦截器
+	WithStreamInterceptors = core.WithStreamInterceptors
+
+	// WithDialOptions 添加自定义 gRPC 拨号选项
+	WithDialOptions = core.WithDialOptions
+
+	// WithOutbox 启用基于本地文件的发件箱
+	WithOutbox = core.WithOutbox
+
+	// WithSuppressionFilter 启用发送前的抑制列表过滤
+	WithSuppressionFilter = core.WithSuppressionFilter
 )
 
 // NewEmailClient 创建一个新的 EmailClient 实例。
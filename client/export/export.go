@@ -0,0 +1,244 @@
+// Package export 将已发送邮件的历史记录导出为 CSV、JSON Lines 或 mbox 格式。
+//
+// 导出按页进行，每写完一页都会产生一个检查点。导出中断后，以最后一个检查点的游标
+// 重新调用 Export 即可从中断处继续，已写出的邮件不会重复。
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Format 导出格式
+type Format string
+
+// 支持的导出格式
+const (
+	FormatCSV   Format = "csv"   // CSV，每封邮件一行，第一行为表头
+	FormatJSONL Format = "jsonl" // JSON Lines，每行是一封邮件的 JSON
+	FormatMbox  Format = "mbox"  // RFC 4155 mbox，每封邮件渲染为完整的 MIME 邮件
+)
+
+// ErrUnsupportedFormat 表示不支持的导出格式
+var ErrUnsupportedFormat = errors.New("不支持的导出格式")
+
+// Source 提供按页获取已发送邮件的能力，*services.EmailServiceClient 实现了该接口
+type Source interface {
+	GetSentEmails(ctx context.Context, req *email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error)
+}
+
+// Checkpoint 导出进度，每写完一页更新一次
+type Checkpoint struct {
+	Cursor   string // 下一页的游标，中断后以此游标继续导出
+	Exported int    // 本次调用已导出的邮件数
+	Done     bool   // 是否已导出全部邮件
+}
+
+// Option 定义导出器配置选项的函数类型
+type Option func(*Exporter)
+
+// WithFilter 设置查询条件，可以使用 services.SentEmailQuery 构建。条件中的游标会被忽略
+func WithFilter(filter *email_client_pb.GetSentEmailsRequest) Option {
+	return func(e *Exporter) {
+		if filter != nil {
+			e.filter = proto.Clone(filter).(*email_client_pb.GetSentEmailsRequest)
+		}
+	}
+}
+
+// WithEmailType 只导出指定类型的邮件
+func WithEmailType(emailType string) Option {
+	return func(e *Exporter) {
+		e.filter.EmailType = emailType
+	}
+}
+
+// WithPageSize 设置每页获取的邮件数，未设置时使用客户端的默认分页大小
+func WithPageSize(size int32) Option {
+	return func(e *Exporter) {
+		if size > 0 {
+			e.filter.Limit = size
+		}
+	}
+}
+
+// WithCheckpoint 设置检查点回调，每写完一页调用一次，可用于持久化导出进度
+func WithCheckpoint(fn func(Checkpoint)) Option {
+	return func(e *Exporter) {
+		e.onCheckpoint = fn
+	}
+}
+
+// Exporter 按页遍历已发送邮件并写出为指定格式
+type Exporter struct {
+	source       Source
+	format       Format
+	filter       *email_client_pb.GetSentEmailsRequest
+	onCheckpoint func(Checkpoint)
+}
+
+// New 创建一个导出器
+func New(source Source, format Format, opts ...Option) *Exporter {
+	e := &Exporter{
+		source: source,
+		format: format,
+		filter: &email_client_pb.GetSentEmailsRequest{},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Export 从 cursor 指定的位置开始导出邮件到 w，cursor 为空表示从头导出
+// 每页邮件先在内存中编码，再一次性写入 w，因此出错时返回的检查点之前的邮件都已完整写出。
+// 中断后以返回的 Checkpoint.Cursor 再次调用即可继续；从头导出时才会写出 CSV 表头。
+func (e *Exporter) Export(ctx context.Context, w io.Writer, cursor string) (Checkpoint, error) {
+	enc, err := e.newEncoder()
+	if err != nil {
+		return Checkpoint{Cursor: cursor}, err
+	}
+
+	req := proto.Clone(e.filter).(*email_client_pb.GetSentEmailsRequest)
+	cp := Checkpoint{Cursor: cursor}
+	var buf bytes.Buffer
+	if cursor == "" {
+		if err := enc.begin(&buf); err != nil {
+			return cp, err
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return cp, err
+		}
+
+		req.Cursor = cp.Cursor
+		resp, err := e.source.GetSentEmails(ctx, req)
+		if err != nil {
+			return cp, err
+		}
+
+		for _, email := range resp.GetEmails() {
+			if err := enc.encode(&buf, email); err != nil {
+				return cp, fmt.Errorf("导出邮件 %s 失败: %w", email.GetId(), err)
+			}
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return cp, err
+		}
+		buf.Reset()
+		cp.Exported += len(resp.GetEmails())
+
+		// 服务端返回空页或重复的游标时停止，避免无限循环
+		next := resp.GetNextCursor()
+		if !resp.GetHasMore() || next == "" || next == cp.Cursor || len(resp.GetEmails()) == 0 {
+			cp.Cursor, cp.Done = "", true
+			e.checkpoint(cp)
+			return cp, nil
+		}
+		cp.Cursor = next
+		e.checkpoint(cp)
+	}
+}
+
+// checkpoint 调用检查点回调
+func (e *Exporter) checkpoint(cp Checkpoint) {
+	if e.onCheckpoint != nil {
+		e.onCheckpoint(cp)
+	}
+}
+
+// encoder 将邮件编码为某种导出格式
+type encoder interface {
+	begin(buf *bytes.Buffer) error                                // 写出文件头，仅在从头导出时调用
+	encode(buf *bytes.Buffer, email *email_client_pb.Email) error // 写出一封邮件
+}
+
+// newEncoder 按导出格式创建编码器
+func (e *Exporter) newEncoder() (encoder, error) {
+	switch e.format {
+	case FormatCSV:
+		return csvEncoder{}, nil
+	case FormatJSONL:
+		return jsonlEncoder{}, nil
+	case FormatMbox:
+		return mboxEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, e.format)
+	}
+}
+
+// csvHeader CSV 导出的表头
+var csvHeader = []string{
+	"id", "sent_at", "email_type", "from", "to", "cc", "bcc", "reply_to",
+	"subject", "text_body", "html_body", "attachments",
+}
+
+// csvEncoder 导出为 CSV，多个地址或附件名以分号分隔
+type csvEncoder struct{}
+
+func (csvEncoder) begin(buf *bytes.Buffer) error {
+	w := csv.NewWriter(buf)
+	w.Write(csvHeader)
+	w.Flush()
+	return w.Error()
+}
+
+func (csvEncoder) encode(buf *bytes.Buffer, email *email_client_pb.Email) error {
+	var sentAt string
+	if email.GetSentAt() != nil {
+		sentAt = email.GetSentAt().AsTime().Format(time.RFC3339)
+	}
+	attachments := make([]string, 0, len(email.GetAttachments()))
+	for _, a := range email.GetAttachments() {
+		attachments = append(attachments, a.GetFilename())
+	}
+	text := email.GetTextBody()
+	if text == "" && email.GetHtmlBody() == "" {
+		text = string(email.GetContent())
+	}
+
+	w := csv.NewWriter(buf)
+	w.Write([]string{
+		email.GetId(),
+		sentAt,
+		email.GetEmailType(),
+		email.GetFrom(),
+		strings.Join(email.GetTo(), ";"),
+		strings.Join(email.GetCc(), ";"),
+		strings.Join(email.GetBcc(), ";"),
+		email.GetReplyTo(),
+		email.GetTitle(),
+		text,
+		email.GetHtmlBody(),
+		strings.Join(attachments, ";"),
+	})
+	w.Flush()
+	return w.Error()
+}
+
+// jsonlEncoder 导出为 JSON Lines，字段名与 proto 定义一致，附件内容以 base64 编码
+type jsonlEncoder struct{}
+
+func (jsonlEncoder) begin(*bytes.Buffer) error { return nil }
+
+func (jsonlEncoder) encode(buf *bytes.Buffer, email *email_client_pb.Email) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(email)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/iwen-conf/email_client/client/mime"
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// mboxEncoder 导出为 RFC 4155 mbox，采用 mboxrd 约定转义正文中以 "From " 开头的行
+type mboxEncoder struct{}
+
+func (mboxEncoder) begin(*bytes.Buffer) error { return nil }
+
+func (mboxEncoder) encode(buf *bytes.Buffer, email *email_client_pb.Email) error {
+	// 导出的是发件人自己的历史记录，与 CSV、JSON Lines 一样保留密送收件人
+	msg, err := mime.Render(email, mime.WithBcc())
+	if err != nil {
+		return err
+	}
+
+	// 分隔行: From <信封发件人> <asctime 格式的时间>，没有发送时间时使用导出时间
+	sender := "MAILER-DAEMON"
+	if addr, err := mail.ParseAddress(email.GetFrom()); err == nil {
+		sender = addr.Address
+	}
+	sentAt := time.Now()
+	if email.GetSentAt() != nil {
+		sentAt = email.GetSentAt().AsTime()
+	}
+	fmt.Fprintf(buf, "From %s %s\n", sender, sentAt.UTC().Format(time.ANSIC))
+
+	// mbox 使用本地换行符，并在以零个或多个 ">" 加 "From " 开头的行前再加一个 ">"
+	msg = bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	for _, line := range bytes.SplitAfter(msg, []byte("\n")) {
+		if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+			buf.WriteByte('>')
+		}
+		buf.Write(line)
+	}
+	if !bytes.HasSuffix(msg, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return nil
+}
@@ -1,10 +1,16 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // CircuitState 表示断路器的状态
@@ -30,6 +36,23 @@ func (s CircuitState) String() string {
 	}
 }
 
+// CircuitOpenError 表示断路器处于开路状态，请求被直接拒绝
+// 它同时携带 Unavailable 状态码，status.Code 等 gRPC 状态函数可以识别它。
+type CircuitOpenError struct {
+	State   CircuitState
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("断路器拒绝请求 (%s): %s", e.State, e.Message)
+}
+
+// GRPCStatus 返回错误对应的 gRPC 状态，状态码为 Unavailable
+func (e *CircuitOpenError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
 // CircuitBreaker 实现断路器模式
 type CircuitBreaker struct {
 	mutex               sync.RWMutex
@@ -190,6 +213,16 @@ func (cb *CircuitBreaker) OnFailure() {
 	}
 }
 
+// release 释放被取消的请求占用的半开状态名额，请求不计入成功或失败
+func (cb *CircuitBreaker) release() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == CircuitHalfOpen && cb.requestCount > 0 {
+		cb.requestCount--
+	}
+}
+
 // State 返回当前断路器状态
 func (cb *CircuitBreaker) State() CircuitState {
 	cb.mutex.RLock()
@@ -219,3 +252,43 @@ func (cb *CircuitBreaker) Reset() {
 		}
 	}
 }
+
+// CircuitBreakerInterceptor 创建一个受断路器保护的一元 gRPC 拦截器
+// 只有可重试的错误（服务不可用、超时等）才会计入失败次数，业务错误视为服务正常响应；
+// 调用方取消的请求既不计入成功也不计入失败。开路时返回的 CircuitOpenError 的状态码为 Unavailable。
+func CircuitBreakerInterceptor(cb *CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, resp interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !cb.IsAllowed() {
+			if cb.debug {
+				log.Printf("[WARN] CircuitBreaker: 断路器拒绝请求 method=%s, state=%s", method, cb.State())
+			}
+			return &CircuitOpenError{
+				State:   cb.State(),
+				Message: "服务连续失败，暂停发送请求",
+			}
+		}
+
+		err := invoker(ctx, method, req, resp, cc, opts...)
+		switch {
+		case isCanceledError(err):
+			cb.release()
+		case err != nil && isRetryableError(err):
+			cb.OnFailure()
+		default:
+			cb.OnSuccess()
+		}
+		return err
+	}
+}
+
+// isCanceledError 判断错误是否由调用方取消请求引起
+func isCanceledError(err error) bool {
+	return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+}
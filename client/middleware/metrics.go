@@ -91,16 +91,18 @@ func (m *ClientMetrics) GetStats() ClientMetricsSnapshot {
 	defer m.mutex.RUnlock()
 
 	var avgRequestTime time.Duration
+	var successRate float64
 	requestCount := atomic.LoadInt64(&m.RequestCount)
 	if requestCount > 0 {
 		avgRequestTime = m.TotalRequestTime / time.Duration(requestCount)
+		successRate = float64(atomic.LoadInt64(&m.RequestSuccessCount)) / float64(requestCount)
 	}
 
 	return ClientMetricsSnapshot{
 		RequestCount:        atomic.LoadInt64(&m.RequestCount),
 		RequestSuccessCount: atomic.LoadInt64(&m.RequestSuccessCount),
 		RequestFailureCount: atomic.LoadInt64(&m.RequestFailureCount),
-		SuccessRate:         float64(atomic.LoadInt64(&m.RequestSuccessCount)) / float64(requestCount),
+		SuccessRate:         successRate,
 		AvgRequestTime:      avgRequestTime,
 		MinRequestTime:      m.MinRequestTime,
 		MaxRequestTime:      m.MaxRequestTime,
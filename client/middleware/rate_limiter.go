@@ -3,8 +3,11 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"log"
 	"sync"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 // RateLimitExceededError 表示超出了速率限制
@@ -192,3 +195,23 @@ func (rl *RateLimiter) SetRate(requestsPerSecond float64) {
 		}
 	}
 }
+
+// RateLimiterInterceptor 创建一个在调用前等待令牌的一元 gRPC 拦截器
+func RateLimiterInterceptor(rl *RateLimiter) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, resp interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if err := rl.Wait(ctx); err != nil {
+			if rl.debug {
+				log.Printf("[WARN] RateLimiter: 请求被速率限制拒绝 method=%s: %v", method, err)
+			}
+			return err
+		}
+		return invoker(ctx, method, req, resp, cc, opts...)
+	}
+}
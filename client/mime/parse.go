@@ -0,0 +1,333 @@
+package mime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	stdmime "mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"golang.org/x/net/html/charset"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrInvalidMessage 表示邮件不是合法的 RFC 5322 / MIME 邮件
+var ErrInvalidMessage = errors.New("邮件格式不合法")
+
+// maxMultipartDepth multipart 允许嵌套的最大层数
+const maxMultipartDepth = 16
+
+// Issue 描述解析时无法在 Email 中完整表示、已被忽略或调整的内容
+type Issue struct {
+	Part   string // 邮件头名称，或 MIME 部分的编号（如 "1.2"，与 IMAP 的部分编号一致）
+	Reason string // 原因
+}
+
+// String 返回便于阅读的描述
+func (i Issue) String() string {
+	return i.Part + ": " + i.Reason
+}
+
+// skippedHeaders 解析时不放入 Email.Headers 的邮件头，它们对应 Email 的字段或由传输过程生成
+var skippedHeaders = map[string]bool{
+	"From":                       true,
+	"To":                         true,
+	"Cc":                         true,
+	"Bcc":                        true,
+	"Reply-To":                   true,
+	"Subject":                    true,
+	"Date":                       true,
+	"Mime-Version":               true,
+	HeaderEmailID:                true,
+	HeaderEmailType:              true,
+	"Received":                   true,
+	"Return-Path":                true,
+	"Delivered-To":               true,
+	"Dkim-Signature":             true,
+	"Authentication-Results":     true,
+	"Arc-Seal":                   true,
+	"Arc-Message-Signature":      true,
+	"Arc-Authentication-Results": true,
+}
+
+// wordDecoder 解码 RFC 2047 编码的邮件头，支持 GBK、Big5 等常见字符集
+var wordDecoder = &stdmime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// Parse 读取一封 .eml 邮件并转换为 Email
+// 标题和地址中的 RFC 2047 编码会被解码，非 UTF-8 字符集的正文会被转换为 UTF-8。
+// 第一个 text/plain 和 text/html 部分分别成为 TextBody 和 HtmlBody，正文的 CRLF 行尾转换为 LF，其余部分成为附件。
+func Parse(r io.Reader) (*email_client_pb.Email, error) {
+	email, _, err := ParseWithIssues(r)
+	return email, err
+}
+
+// ParseWithIssues 与 Parse 相同，同时返回无法在 Email 中完整表示的内容，
+// 例如多个发件人、不是由邮件ID生成的 Message-ID、没有 Content-ID 的内嵌资源、数字签名以及无法识别的字符集。
+func ParseWithIssues(r io.Reader) (*email_client_pb.Email, []Issue, error) {
+	msg, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+
+	p := &parser{email: &email_client_pb.Email{
+		Id:        msg.Header.Get(HeaderEmailID),
+		EmailType: msg.Header.Get(HeaderEmailType),
+	}}
+	p.email.Title = p.decodeHeader("Subject", msg.Header.Get("Subject"))
+	if from := p.addressList(msg.Header, "From"); len(from) > 0 {
+		p.email.From = from[0]
+		if len(from) > 1 {
+			p.issue("From", "有多个发件人，只保留第一个")
+		}
+	}
+	p.email.To = p.addressList(msg.Header, "To")
+	p.email.Cc = p.addressList(msg.Header, "Cc")
+	p.email.Bcc = p.addressList(msg.Header, "Bcc")
+	if replyTo := p.addressList(msg.Header, "Reply-To"); len(replyTo) > 0 {
+		p.email.ReplyTo = replyTo[0]
+		if len(replyTo) > 1 {
+			p.issue("Reply-To", "有多个回复地址，只保留第一个")
+		}
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		p.email.SentAt = timestamppb.New(date)
+	}
+
+	names := make([]string, 0, len(msg.Header))
+	for name := range msg.Header {
+		names = append(names, textproto.CanonicalMIMEHeaderKey(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := msg.Header[name]
+		if skippedHeaders[name] || strings.HasPrefix(name, "Content-") || len(values) == 0 {
+			continue
+		}
+		if name == "Message-Id" {
+			// 由邮件ID生成的 Message-ID 在重新渲染时可以还原
+			if p.email.GetId() == "" || values[0] != messageID(p.email) {
+				p.issue(name, "Email 中没有对应的字段，已忽略")
+			}
+			continue
+		}
+		if len(values) > 1 {
+			p.issue(name, fmt.Sprintf("出现了 %d 次，只保留第一个值", len(values)))
+		}
+		if p.email.Headers == nil {
+			p.email.Headers = make(map[string]string)
+		}
+		p.email.Headers[name] = p.decodeHeader(name, values[0])
+	}
+
+	if err := p.parsePart(textproto.MIMEHeader(msg.Header), msg.Body, "", "", 0); err != nil {
+		return nil, nil, err
+	}
+	return p.email, p.issues, nil
+}
+
+// parser 保存解析过程中的邮件和问题列表
+type parser struct {
+	email  *email_client_pb.Email
+	issues []Issue
+}
+
+// issue 记录一个无法完整表示的内容
+func (p *parser) issue(part, reason string) {
+	p.issues = append(p.issues, Issue{Part: part, Reason: reason})
+}
+
+// parsePart 递归解析 MIME 部分，将正文和附件写入邮件
+// path 为部分编号，顶层为空；parent 为上一层 multipart 的媒体类型。
+func (p *parser) parsePart(header textproto.MIMEHeader, body io.Reader, path, parent string, depth int) error {
+	part := path
+	if part == "" {
+		part = "1"
+	}
+
+	mediaType, params, err := stdmime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		if header.Get("Content-Type") != "" {
+			p.issue(part, fmt.Sprintf("无法解析 Content-Type %q，按 text/plain 处理", header.Get("Content-Type")))
+		}
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxMultipartDepth {
+			return fmt.Errorf("%w: multipart 嵌套超过 %d 层", ErrInvalidMessage, maxMultipartDepth)
+		}
+		if params["boundary"] == "" {
+			return fmt.Errorf("%w: %s 缺少 boundary", ErrInvalidMessage, mediaType)
+		}
+		switch mediaType {
+		case "multipart/signed":
+			p.issue(part, "数字签名无法保留，签名部分作为附件保留")
+		case "multipart/encrypted":
+			p.issue(part, "加密内容无法解密，作为附件保留")
+		}
+
+		reader := multipart.NewReader(body, params["boundary"])
+		for i := 1; ; i++ {
+			child, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+			}
+			childPath := strconv.Itoa(i)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			if err := p.parsePart(child.Header, child, childPath, mediaType, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("%w: 解码正文失败: %v", ErrInvalidMessage, err)
+	}
+
+	disposition, dispParams, _ := stdmime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := p.decodeHeader(part, dispParams["filename"])
+	if filename == "" {
+		filename = p.decodeHeader(part, params["name"])
+	}
+
+	// 没有文件名且不是附件的文本部分作为正文
+	if disposition != "attachment" && filename == "" {
+		switch {
+		// 传输时的 CRLF 行尾还原为 LF，与渲染前的正文一致
+		case mediaType == "text/plain" && p.email.TextBody == "":
+			p.email.TextBody = strings.ReplaceAll(p.decodeCharset(part, params["charset"], content), "\r\n", "\n")
+			return nil
+		case mediaType == "text/html" && p.email.HtmlBody == "":
+			p.email.HtmlBody = strings.ReplaceAll(p.decodeCharset(part, params["charset"], content), "\r\n", "\n")
+			return nil
+		case mediaType == "text/plain" || mediaType == "text/html":
+			p.issue(part, "多余的 "+mediaType+" 正文作为附件保留")
+		case parent == "multipart/alternative":
+			p.issue(part, "Email 只支持 text/plain 和 text/html 正文，"+mediaType+" 作为附件保留")
+		}
+	}
+
+	attachment := &email_client_pb.Attachment{
+		Filename:     filename,
+		Content:      content,
+		ContentType:  mediaType,
+		Size:         int64(len(content)),
+		AttachmentId: header.Get(HeaderAttachmentID),
+		ContentId:    strings.Trim(header.Get("Content-Id"), "<>"),
+	}
+	if attachment.Filename == "" {
+		attachment.Filename = "part-" + part + extensionByType(mediaType)
+	}
+	if disposition == "inline" || (disposition == "" && parent == "multipart/related") {
+		if attachment.ContentId != "" {
+			attachment.Disposition = email_client_pb.Attachment_INLINE
+		} else if !strings.HasPrefix(mediaType, "text/") {
+			p.issue(part, "内嵌资源没有 Content-ID，作为普通附件保留")
+		}
+	}
+	p.email.Attachments = append(p.email.Attachments, attachment)
+	return nil
+}
+
+// extensionByType 返回媒体类型对应的文件扩展名，用于为没有文件名的部分生成文件名
+func extensionByType(mediaType string) string {
+	switch mediaType {
+	case "text/plain":
+		return ".txt"
+	case "text/html":
+		return ".html"
+	case "message/rfc822":
+		return ".eml"
+	}
+	if exts, _ := stdmime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// decodeCharset 将指定字符集的文本转换为 UTF-8，不认识的字符集原样保留
+func (p *parser) decodeCharset(part, label string, content []byte) string {
+	switch strings.ToLower(label) {
+	case "", "utf-8", "utf8", "us-ascii":
+		return string(content)
+	}
+	r, err := charset.NewReaderLabel(label, bytes.NewReader(content))
+	if err == nil {
+		var decoded []byte
+		if decoded, err = io.ReadAll(r); err == nil {
+			return string(decoded)
+		}
+	}
+	p.issue(part, fmt.Sprintf("无法识别字符集 %q，正文按原始字节保留", label))
+	return string(content)
+}
+
+// decodeHeader 解码 RFC 2047 编码的邮件头，解码失败时原样保留
+func (p *parser) decodeHeader(name, value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		p.issue(name, "无法解码 RFC 2047 编码，按原文保留")
+		return value
+	}
+	return decoded
+}
+
+// addressList 解析地址列表邮件头，返回 "显示名称 <地址>" 形式的地址，解析失败时原样保留邮件头的值
+func (p *parser) addressList(header mail.Header, name string) []string {
+	value := header.Get(name)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parser := mail.AddressParser{WordDecoder: wordDecoder}
+	addrs, err := parser.ParseList(value)
+	if err != nil {
+		p.issue(name, "地址无法解析，按原文保留")
+		return []string{p.decodeHeader(name, value)}
+	}
+	list := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		list = append(list, formatAddress(addr))
+	}
+	return list
+}
+
+// decodeTransfer 按 Content-Transfer-Encoding 解码正文
+// multipart.Reader 已自动解码 quoted-printable 的部分并删除了该邮件头，这里只处理其余情况。
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// formatAddress 将地址格式化为 "显示名称 <地址>"，显示名称保持解码后的 UTF-8 文本
+func formatAddress(addr *mail.Address) string {
+	if addr.Name == "" {
+		return addr.Address
+	}
+	name := addr.Name
+	if strings.ContainsAny(name, "()<>[]:;@\\,.\"") {
+		name = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+	}
+	return name + " <" + addr.Address + ">"
+}
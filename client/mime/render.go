@@ -0,0 +1,337 @@
+// Package mime 在本地将 Email 渲染为符合 RFC 5322 / MIME 的邮件，并将 .eml 邮件解析回 Email。
+//
+// 渲染结果可用于预览邮件在网络上传输时的样子、归档或导出。邮件头中的非 ASCII 文本按 RFC 2047
+// 编码，文本正文使用 quoted-printable，附件使用 base64。
+package mime
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	stdmime "mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"golang.org/x/net/idna"
+)
+
+// 客户端写入的扩展邮件头，用于在渲染和解析之间保留 Email 中没有对应标准邮件头的字段
+const (
+	HeaderEmailID      = "X-Email-Id"      // 邮件唯一ID
+	HeaderEmailType    = "X-Email-Type"    // 邮件类型
+	HeaderAttachmentID = "X-Attachment-Id" // 只有附件ID而没有内容的附件
+)
+
+// maxLineLength 邮件头折行和 base64 换行的长度
+const maxLineLength = 76
+
+// entity 是 MIME 邮件中的一个部分，body 已按 Content-Transfer-Encoding 编码
+type entity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// Option 定义渲染选项的函数类型
+type Option func(*renderOptions)
+
+// renderOptions 渲染选项
+type renderOptions struct {
+	bcc bool // 是否写入 Bcc 邮件头
+}
+
+// WithBcc 在渲染结果中保留 Bcc 邮件头，用于发件人自己的归档
+// 默认不写入 Bcc，避免渲染结果被转发或中继时泄露密送收件人。
+func WithBcc() Option {
+	return func(o *renderOptions) {
+		o.bcc = true
+	}
+}
+
+// Render 将邮件渲染为 RFC 5322 / MIME 格式，行尾为 CRLF
+// 正文和附件的结构为 multipart/mixed(multipart/related(multipart/alternative(text, html), 内嵌资源), 附件)，
+// 只有一个子部分的层级会被省略。只有附件ID而没有内容的附件以空正文和 X-Attachment-Id 头表示。
+// 邮件没有发送时间时 Date 使用当前时间；密送收件人默认不写入，需要时使用 WithBcc。
+// 没有自定义 Message-ID 时根据邮件ID和发件人域名生成，同一封邮件每次渲染得到相同的 Message-ID。
+func Render(email *email_client_pb.Email, opts ...Option) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Write(&buf, email, opts...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write 将邮件渲染为 RFC 5322 / MIME 格式并写入 w
+func Write(w io.Writer, email *email_client_pb.Email, opts ...Option) error {
+	var options renderOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	body, err := messageBody(email)
+	if err != nil {
+		return err
+	}
+
+	// RFC 5322 要求必须有 Date 邮件头
+	date := time.Now()
+	if email.GetSentAt() != nil {
+		date = email.GetSentAt().AsTime()
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "Date", date.Format(time.RFC1123Z))
+	if !hasHeader(email, "Message-Id") {
+		writeHeader(&buf, "Message-ID", messageID(email))
+	}
+	writeHeader(&buf, "From", formatAddressList([]string{email.GetFrom()}))
+	var bcc []string
+	if options.bcc {
+		bcc = email.GetBcc()
+	}
+	for _, h := range []struct {
+		name  string
+		addrs []string
+	}{
+		{"To", email.GetTo()},
+		{"Cc", email.GetCc()},
+		{"Bcc", bcc},
+		{"Reply-To", []string{email.GetReplyTo()}},
+	} {
+		if list := formatAddressList(h.addrs); list != "" {
+			writeHeader(&buf, h.name, list)
+		}
+	}
+	writeHeader(&buf, "Subject", stdmime.QEncoding.Encode("utf-8", email.GetTitle()))
+	if email.GetId() != "" {
+		writeHeader(&buf, HeaderEmailID, email.GetId())
+	}
+	if email.GetEmailType() != "" {
+		writeHeader(&buf, HeaderEmailType, email.GetEmailType())
+	}
+
+	// 自定义邮件头按名称排序，保证输出稳定
+	names := make([]string, 0, len(email.GetHeaders()))
+	for name := range email.GetHeaders() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(&buf, textproto.CanonicalMIMEHeaderKey(name), stdmime.QEncoding.Encode("utf-8", email.GetHeaders()[name]))
+	}
+
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeEntityHeader(&buf, body.header)
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// hasHeader 判断邮件的自定义邮件头中是否有指定名称的邮件头，名称不区分大小写
+func hasHeader(email *email_client_pb.Email, name string) bool {
+	for key := range email.GetHeaders() {
+		if textproto.CanonicalMIMEHeaderKey(key) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// messageID 返回邮件的 Message-ID，形如 <邮件ID@发件人域名>
+// 邮件ID包含不能出现在 Message-ID 中的字符时使用其哈希值，没有邮件ID时使用随机值。
+func messageID(email *email_client_pb.Email) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(email.GetFrom()); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			if ascii, err := idna.Lookup.ToASCII(addr.Address[at+1:]); err == nil && ascii != "" {
+				domain = ascii
+			}
+		}
+	}
+
+	id := email.GetId()
+	switch {
+	case id == "":
+		var b [16]byte
+		rand.Read(b[:])
+		id = hex.EncodeToString(b[:])
+	case strings.IndexFunc(id, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.')
+	}) >= 0:
+		sum := sha256.Sum256([]byte(id))
+		id = hex.EncodeToString(sum[:16])
+	}
+	return "<" + id + "@" + domain + ">"
+}
+
+// messageBody 按正文、内嵌资源和附件组装邮件主体
+func messageBody(email *email_client_pb.Email) (entity, error) {
+	var alternatives []entity
+	if text := email.GetTextBody(); text != "" {
+		alternatives = append(alternatives, textEntity("text/plain", text))
+	}
+	if html := email.GetHtmlBody(); html != "" {
+		alternatives = append(alternatives, textEntity("text/html", html))
+	}
+	if len(alternatives) == 0 {
+		alternatives = append(alternatives, textEntity("text/plain", string(email.GetContent())))
+	}
+
+	var inlines, attachments []entity
+	for _, a := range email.GetAttachments() {
+		if a.GetDisposition() == email_client_pb.Attachment_INLINE {
+			inlines = append(inlines, attachmentEntity(a))
+		} else {
+			attachments = append(attachments, attachmentEntity(a))
+		}
+	}
+
+	body, err := multipartEntity("alternative", alternatives)
+	if err != nil {
+		return entity{}, err
+	}
+	if body, err = multipartEntity("related", append([]entity{body}, inlines...)); err != nil {
+		return entity{}, err
+	}
+	return multipartEntity("mixed", append([]entity{body}, attachments...))
+}
+
+// textEntity 创建 UTF-8 编码的文本部分，使用 quoted-printable 传输编码
+func textEntity(mediaType, text string) entity {
+	var body bytes.Buffer
+	w := quotedprintable.NewWriter(&body)
+	w.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")))
+	w.Close()
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", stdmime.FormatMediaType(mediaType, map[string]string{"charset": "utf-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return entity{header: header, body: body.Bytes()}
+}
+
+// attachmentEntity 创建附件部分，使用 base64 传输编码
+func attachmentEntity(a *email_client_pb.Attachment) entity {
+	contentType := a.GetContentType()
+	if contentType == "" {
+		contentType = stdmime.TypeByExtension(path.Ext(a.GetFilename()))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := "attachment"
+	if a.GetDisposition() == email_client_pb.Attachment_INLINE {
+		disposition = "inline"
+	}
+
+	header := textproto.MIMEHeader{}
+	if mediaType, params, err := stdmime.ParseMediaType(contentType); err == nil {
+		if a.GetFilename() != "" {
+			params["name"] = a.GetFilename()
+		}
+		header.Set("Content-Type", stdmime.FormatMediaType(mediaType, params))
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+	if a.GetFilename() != "" {
+		header.Set("Content-Disposition", stdmime.FormatMediaType(disposition, map[string]string{"filename": a.GetFilename()}))
+	} else {
+		header.Set("Content-Disposition", disposition)
+	}
+	if a.GetContentId() != "" {
+		header.Set("Content-ID", "<"+a.GetContentId()+">")
+	}
+	if a.GetAttachmentId() != "" && len(a.GetContent()) == 0 {
+		header.Set(HeaderAttachmentID, a.GetAttachmentId())
+	}
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	encoded := base64.StdEncoding.EncodeToString(a.GetContent())
+	var body bytes.Buffer
+	for len(encoded) > maxLineLength {
+		body.WriteString(encoded[:maxLineLength] + "\r\n")
+		encoded = encoded[maxLineLength:]
+	}
+	if encoded != "" {
+		body.WriteString(encoded + "\r\n")
+	}
+	return entity{header: header, body: body.Bytes()}
+}
+
+// multipartEntity 将多个部分组合为 multipart/<subtype>，只有一个部分时直接返回该部分
+func multipartEntity(subtype string, parts []entity) (entity, error) {
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range parts {
+		pw, err := w.CreatePart(p.header)
+		if err != nil {
+			return entity{}, err
+		}
+		if _, err := pw.Write(p.body); err != nil {
+			return entity{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return entity{}, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", stdmime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()}))
+	return entity{header: header, body: body.Bytes()}, nil
+}
+
+// formatAddressList 将地址列表格式化为邮件头的值，显示名称按 RFC 2047 编码，无法解析的地址原样保留
+func formatAddressList(addrs []string) string {
+	formatted := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		if strings.TrimSpace(a) == "" {
+			continue
+		}
+		if addr, err := mail.ParseAddress(a); err == nil {
+			formatted = append(formatted, addr.String())
+		} else {
+			formatted = append(formatted, a)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// writeEntityHeader 按名称顺序写出 MIME 部分的头
+func writeEntityHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range header[name] {
+			writeHeader(buf, name, v)
+		}
+	}
+}
+
+// writeHeader 写出一个邮件头，超过长度限制时在空格处折行
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	line := name + ":"
+	for _, word := range strings.Split(value, " ") {
+		if word != "" && len(line)+1+len(word) > maxLineLength && strings.Contains(line, " ") {
+			buf.WriteString(line + "\r\n")
+			line = ""
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\r\n")
+}
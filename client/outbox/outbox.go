@@ -0,0 +1,658 @@
+// Package outbox 提供基于本地文件的发件箱，在服务端不可达时持久化待发送的邮件，
+// 并在连接恢复后按入队顺序重新发送。
+//
+// 发件箱使用目录下的 outbox.log 作为只追加的日志，每行是一条 JSON 记录，
+// 进程重启后通过重放日志恢复队列。每次排空队列后日志会被压缩，只保留尚未完成的记录。
+//
+// 通过 WithAutoDrain 启用自动发送后，发件箱在有邮件入队时于后台按退避间隔重试发送，
+// 不依赖连接状态的变化，因此连接始终保持 Ready 时队列也能被排空。
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/email_client/client/middleware"
+	"github.com/iwen-conf/email_client/client/services"
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxAttempts 一封邮件最多尝试发送的次数，超过后转入死信队列
+const DefaultMaxAttempts = 10
+
+// DefaultRetryInterval 自动发送时首次重试前的等待时间
+const DefaultRetryInterval = 5 * time.Second
+
+// maxRetryInterval 自动发送时重试间隔的上限
+const maxRetryInterval = 5 * time.Minute
+
+// logFileName 发件箱日志文件名
+const logFileName = "outbox.log"
+
+// 日志记录的操作类型
+const (
+	opEnqueue = "enqueue" // 入队
+	opAttempt = "attempt" // 发送失败，记录一次尝试
+	opAck     = "ack"     // 发送成功，移出队列
+	opDead    = "dead"    // 转入死信队列
+	opRequeue = "requeue" // 从死信队列重新入队
+	opDiscard = "discard" // 从死信队列删除
+)
+
+var (
+	// ErrEntryNotFound 表示指定的死信不存在
+	ErrEntryNotFound = errors.New("发件箱中不存在该邮件")
+
+	// ErrOutboxClosed 表示发件箱已关闭
+	ErrOutboxClosed = errors.New("发件箱已关闭")
+)
+
+// SendFunc 发送一封邮件，通常为 EmailServiceClient.SendEmail
+type SendFunc func(ctx context.Context, req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error)
+
+// Option 定义发件箱配置选项的函数类型
+type Option func(*Outbox)
+
+// WithMaxAttempts 设置一封邮件最多尝试发送的次数，超过后转入死信队列
+func WithMaxAttempts(n int) Option {
+	return func(o *Outbox) {
+		if n > 0 {
+			o.maxAttempts = n
+		}
+	}
+}
+
+// WithAutoDrain 启用后台自动发送，interval 为邮件入队后首次尝试发送前的等待时间
+// 发送失败时等待时间按指数增长，最长 5 分钟；interval 为 0 时不自动发送（默认），需要调用方调用 Drain。
+func WithAutoDrain(interval time.Duration) Option {
+	return func(o *Outbox) {
+		o.retryInterval = max(interval, 0)
+	}
+}
+
+// WithDebug 设置是否输出调试日志
+func WithDebug(debug bool) Option {
+	return func(o *Outbox) {
+		o.debug = debug
+	}
+}
+
+// Entry 是发件箱中的一封邮件
+type Entry struct {
+	ID         string                            // 发件箱内的唯一ID
+	Request    *email_client_pb.SendEmailRequest // 发送请求
+	EnqueuedAt time.Time                         // 入队时间
+	Attempts   int                               // 已尝试发送的次数
+	LastError  string                            // 最近一次发送失败的原因
+}
+
+// record 是日志中的一条记录
+type record struct {
+	Op       string          `json:"op"`
+	ID       string          `json:"id"`
+	Time     time.Time       `json:"time"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Attempts int             `json:"attempts,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Outbox 是基于本地文件的发件箱，可以被多个 goroutine 并发使用
+type Outbox struct {
+	dir           string
+	send          SendFunc
+	maxAttempts   int
+	retryInterval time.Duration // 自动发送的首次重试间隔，为 0 表示不自动发送
+	debug         bool
+
+	mu      sync.Mutex
+	file    *os.File
+	pending []*Entry          // 待发送队列，按入队顺序排列
+	dead    []*Entry          // 死信队列
+	index   map[string]*Entry // 所有邮件按ID索引
+
+	drainMu sync.Mutex // 保证同一时刻只有一个排空过程
+
+	wake    chan struct{}      // 通知后台有邮件入队
+	cancel  context.CancelFunc // 停止后台自动发送
+	stopped chan struct{}      // 后台自动发送结束后关闭
+}
+
+// Open 打开 dir 目录下的发件箱，目录不存在时自动创建，已有日志会被重放以恢复队列
+func Open(dir string, send SendFunc, opts ...Option) (*Outbox, error) {
+	o := &Outbox{
+		dir:         dir,
+		send:        send,
+		maxAttempts: DefaultMaxAttempts,
+		index:       make(map[string]*Entry),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("创建发件箱目录失败: %w", err)
+	}
+	if err := o.replay(); err != nil {
+		return nil, err
+	}
+	if err := o.compact(); err != nil {
+		return nil, err
+	}
+
+	if o.debug {
+		log.Printf("[INFO] Outbox: 已打开发件箱 %s，待发送 %d 封，死信 %d 封", dir, len(o.pending), len(o.dead))
+	}
+
+	if o.retryInterval > 0 {
+		var ctx context.Context
+		ctx, o.cancel = context.WithCancel(context.Background())
+		o.wake = make(chan struct{}, 1)
+		o.stopped = make(chan struct{})
+		go o.autoDrain(ctx)
+		if len(o.pending) > 0 {
+			o.notify()
+		}
+	}
+	return o, nil
+}
+
+// Send 发送邮件，服务端不可达时将邮件持久化到发件箱并返回 queued 为 true
+// 为保证发送顺序，发件箱中已有待发送的邮件时新邮件直接入队。
+// 被客户端速率限制或断路器拒绝的邮件不会入队，错误直接返回给调用方。
+// 请求未设置幂等键时会在请求的副本上生成一个，使连接恢复后的重新发送可以被服务端去重，调用方的请求保持不变。
+func (o *Outbox) Send(ctx context.Context, req *email_client_pb.SendEmailRequest) (resp *email_client_pb.SendEmailResponse, queued bool, err error) {
+	if req.GetIdempotencyKey() == "" {
+		req = proto.Clone(req).(*email_client_pb.SendEmailRequest)
+		req.IdempotencyKey = services.NewIdempotencyKey()
+	}
+
+	if o.Depth() == 0 {
+		resp, err = o.send(ctx, req)
+		if err == nil || !isTransient(err) {
+			return resp, false, err
+		}
+		if o.debug {
+			log.Printf("[WARN] Outbox: 发送失败，邮件转入发件箱: %v", err)
+		}
+	}
+
+	if _, err := o.Enqueue(req); err != nil {
+		return nil, false, err
+	}
+	return nil, true, nil
+}
+
+// Enqueue 将发送请求持久化到发件箱队尾，返回发件箱内的ID
+// 发件箱保存请求的副本，请求未设置幂等键时在副本上生成一个。
+func (o *Outbox) Enqueue(req *email_client_pb.SendEmailRequest) (string, error) {
+	req = proto.Clone(req).(*email_client_pb.SendEmailRequest)
+	if req.GetIdempotencyKey() == "" {
+		req.IdempotencyKey = services.NewIdempotencyKey()
+	}
+	data, err := protojson.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("序列化发送请求失败: %w", err)
+	}
+
+	entry := &Entry{
+		ID:         services.NewIdempotencyKey(),
+		Request:    req,
+		EnqueuedAt: time.Now(),
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.write(record{Op: opEnqueue, ID: entry.ID, Time: entry.EnqueuedAt, Request: data}); err != nil {
+		return "", err
+	}
+	o.pending = append(o.pending, entry)
+	o.index[entry.ID] = entry
+	o.notify()
+	return entry.ID, nil
+}
+
+// Drain 按入队顺序发送发件箱中的邮件，返回发送成功的数量
+// 遇到连接类错误时停止并返回该错误，等待下次连接恢复后继续；服务端拒绝的邮件或超过最大尝试次数的邮件转入死信队列。
+// 被客户端速率限制或断路器拒绝时同样停止，但不计入尝试次数。
+func (o *Outbox) Drain(ctx context.Context) (int, error) {
+	o.drainMu.Lock()
+	defer o.drainMu.Unlock()
+
+	sent := 0
+	defer func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if o.file != nil {
+			if err := o.compact(); err != nil {
+				log.Printf("[ERROR] Outbox: 压缩发件箱日志失败: %v", err)
+			}
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		o.mu.Lock()
+		if len(o.pending) == 0 {
+			o.mu.Unlock()
+			return sent, nil
+		}
+		entry := o.pending[0]
+		o.mu.Unlock()
+
+		resp, err := o.send(ctx, proto.Clone(entry.Request).(*email_client_pb.SendEmailRequest))
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// 排空过程被取消，邮件保留在队列中
+			return sent, ctx.Err()
+
+		case isLocalRejection(err):
+			// 请求没有到达服务端，稍后重试
+			return sent, err
+
+		case err == nil && resp.GetSuccess():
+			if err := o.finish(entry, record{Op: opAck, ID: entry.ID}); err != nil {
+				return sent, err
+			}
+			sent++
+
+		case err == nil:
+			// 服务端明确拒绝，重试也不会成功
+			if err := o.finish(entry, record{Op: opDead, ID: entry.ID, Error: resp.GetMessage()}); err != nil {
+				return sent, err
+			}
+
+		case !isTransient(err) || entry.Attempts+1 >= o.maxAttempts:
+			if err := o.finish(entry, record{Op: opDead, ID: entry.ID, Error: err.Error()}); err != nil {
+				return sent, err
+			}
+
+		default:
+			o.mu.Lock()
+			writeErr := o.write(record{Op: opAttempt, ID: entry.ID, Time: time.Now(), Error: err.Error()})
+			if writeErr == nil {
+				entry.Attempts++
+				entry.LastError = err.Error()
+			}
+			o.mu.Unlock()
+			if writeErr != nil {
+				return sent, writeErr
+			}
+			if o.debug {
+				log.Printf("[WARN] Outbox: 发送发件箱邮件 %s 失败，等待连接恢复后重试: %v", entry.ID, err)
+			}
+			return sent, err
+		}
+	}
+}
+
+// Depth 返回待发送的邮件数
+func (o *Outbox) Depth() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+// Pending 返回待发送邮件的副本，按发送顺序排列
+func (o *Outbox) Pending() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return copyEntries(o.pending)
+}
+
+// DeadLetters 返回死信队列中邮件的副本
+func (o *Outbox) DeadLetters() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return copyEntries(o.dead)
+}
+
+// Requeue 将死信重新放入待发送队列的队尾，并清零尝试次数
+func (o *Outbox) Requeue(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	i := findEntry(o.dead, id)
+	if i < 0 {
+		return ErrEntryNotFound
+	}
+	if err := o.write(record{Op: opRequeue, ID: id, Time: time.Now()}); err != nil {
+		return err
+	}
+	entry := o.dead[i]
+	o.dead = append(o.dead[:i], o.dead[i+1:]...)
+	entry.Attempts, entry.LastError = 0, ""
+	o.pending = append(o.pending, entry)
+	o.notify()
+	return nil
+}
+
+// Discard 从死信队列中删除邮件
+func (o *Outbox) Discard(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	i := findEntry(o.dead, id)
+	if i < 0 {
+		return ErrEntryNotFound
+	}
+	if err := o.write(record{Op: opDiscard, ID: id, Time: time.Now()}); err != nil {
+		return err
+	}
+	o.dead = append(o.dead[:i], o.dead[i+1:]...)
+	delete(o.index, id)
+	return nil
+}
+
+// Close 停止后台自动发送并关闭发件箱日志文件，未发送的邮件在下次 Open 时恢复
+func (o *Outbox) Close() error {
+	if o.cancel != nil {
+		o.cancel()
+		<-o.stopped
+	}
+	o.drainMu.Lock()
+	defer o.drainMu.Unlock()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+	err := o.file.Close()
+	o.file = nil
+	return err
+}
+
+// notify 通知后台自动发送有待发送的邮件，未启用自动发送时不做任何事
+func (o *Outbox) notify() {
+	if o.wake == nil {
+		return
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// autoDrain 在有邮件入队后等待重试间隔并排空队列，发送失败时按指数退避重试，直到队列为空
+func (o *Outbox) autoDrain(ctx context.Context) {
+	defer close(o.stopped)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.wake:
+		}
+
+		for delay := o.retryInterval; ; delay = min(delay*2, maxRetryInterval) {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			sent, err := o.Drain(ctx)
+			if err == nil {
+				if o.debug && sent > 0 {
+					log.Printf("[INFO] Outbox: 已自动发送发件箱中的 %d 封邮件", sent)
+				}
+				break
+			}
+			if o.debug {
+				log.Printf("[WARN] Outbox: 自动发送中断，稍后重试: %v", err)
+			}
+		}
+	}
+}
+
+// finish 将待发送队列队首的邮件确认发送或转入死信队列
+func (o *Outbox) finish(entry *Entry, rec record) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	rec.Time = time.Now()
+	if err := o.write(rec); err != nil {
+		return err
+	}
+	if i := findEntry(o.pending, entry.ID); i >= 0 {
+		o.pending = append(o.pending[:i], o.pending[i+1:]...)
+	}
+	if rec.Op == opDead {
+		entry.LastError = rec.Error
+		o.dead = append(o.dead, entry)
+		if o.debug {
+			log.Printf("[WARN] Outbox: 邮件 %s 转入死信队列: %s", entry.ID, rec.Error)
+		}
+	} else {
+		delete(o.index, entry.ID)
+	}
+	return nil
+}
+
+// write 追加一条日志记录并同步到磁盘，调用方需持有 mu
+func (o *Outbox) write(rec record) error {
+	if o.file == nil {
+		return ErrOutboxClosed
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("写入发件箱日志失败: %w", err)
+	}
+	return o.file.Sync()
+}
+
+// replay 重放日志文件，恢复待发送队列和死信队列
+func (o *Outbox) replay() error {
+	file, err := os.Open(filepath.Join(o.dir, logFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("打开发件箱日志失败: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// 进程在写入过程中退出时最后一行可能不完整
+			log.Printf("[WARN] Outbox: 跳过发件箱日志第 %d 行无法解析的记录: %v", line, err)
+			continue
+		}
+		if err := o.apply(rec); err != nil {
+			log.Printf("[WARN] Outbox: 跳过发件箱日志第 %d 行: %v", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取发件箱日志失败: %w", err)
+	}
+	return nil
+}
+
+// apply 将一条日志记录应用到内存中的队列
+func (o *Outbox) apply(rec record) error {
+	if rec.Op == opEnqueue {
+		req := &email_client_pb.SendEmailRequest{}
+		if err := protojson.Unmarshal(rec.Request, req); err != nil {
+			return fmt.Errorf("解析发送请求失败: %w", err)
+		}
+		entry := &Entry{ID: rec.ID, Request: req, EnqueuedAt: rec.Time, Attempts: rec.Attempts, LastError: rec.Error}
+		o.pending = append(o.pending, entry)
+		o.index[rec.ID] = entry
+		return nil
+	}
+
+	entry, ok := o.index[rec.ID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrEntryNotFound, rec.ID)
+	}
+	switch rec.Op {
+	case opAttempt:
+		entry.Attempts++
+		entry.LastError = rec.Error
+	case opAck:
+		o.pending = removeEntry(o.pending, rec.ID)
+		delete(o.index, rec.ID)
+	case opDead:
+		o.pending = removeEntry(o.pending, rec.ID)
+		entry.LastError = rec.Error
+		o.dead = append(o.dead, entry)
+	case opRequeue:
+		o.dead = removeEntry(o.dead, rec.ID)
+		entry.Attempts, entry.LastError = 0, ""
+		o.pending = append(o.pending, entry)
+	case opDiscard:
+		o.dead = removeEntry(o.dead, rec.ID)
+		delete(o.index, rec.ID)
+	default:
+		return fmt.Errorf("未知的操作类型 %q", rec.Op)
+	}
+	return nil
+}
+
+// compact 将当前队列重写为新的日志文件并替换旧文件，调用方需持有 mu
+// 死信以一条入队记录加一条死信记录的形式保留。
+func (o *Outbox) compact() error {
+	path := filepath.Join(o.dir, logFileName)
+	tmp, err := os.CreateTemp(o.dir, logFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("创建发件箱临时日志失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	writeRecord := func(rec record) error {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(append(data, '\n'))
+		return err
+	}
+	writeEntry := func(entry *Entry, dead bool) error {
+		data, err := protojson.Marshal(entry.Request)
+		if err != nil {
+			return err
+		}
+		rec := record{Op: opEnqueue, ID: entry.ID, Time: entry.EnqueuedAt, Request: data, Attempts: entry.Attempts}
+		if !dead {
+			rec.Error = entry.LastError
+		}
+		if err := writeRecord(rec); err != nil {
+			return err
+		}
+		if dead {
+			return writeRecord(record{Op: opDead, ID: entry.ID, Time: entry.EnqueuedAt, Error: entry.LastError})
+		}
+		return nil
+	}
+
+	for _, entry := range o.pending {
+		if err := writeEntry(entry, false); err != nil {
+			tmp.Close()
+			return fmt.Errorf("写入发件箱临时日志失败: %w", err)
+		}
+	}
+	for _, entry := range o.dead {
+		if err := writeEntry(entry, true); err != nil {
+			tmp.Close()
+			return fmt.Errorf("写入发件箱临时日志失败: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入发件箱临时日志失败: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("同步发件箱临时日志失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("关闭发件箱临时日志失败: %w", err)
+	}
+
+	if o.file != nil {
+		o.file.Close()
+		o.file = nil
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("替换发件箱日志失败: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("打开发件箱日志失败: %w", err)
+	}
+	o.file = file
+	return nil
+}
+
+// isLocalRejection 判断请求是否被客户端的速率限制或断路器拒绝，此时请求没有发往服务端
+func isLocalRejection(err error) bool {
+	var circuitErr *middleware.CircuitOpenError
+	var rateErr *middleware.RateLimitExceededError
+	return errors.As(err, &circuitErr) || errors.As(err, &rateErr)
+}
+
+// isTransient 判断发送错误是否由服务端暂时不可达引起
+func isTransient(err error) bool {
+	if isLocalRejection(err) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// copyEntries 复制邮件列表，避免调用方修改发件箱内部状态
+func copyEntries(entries []*Entry) []Entry {
+	result := make([]Entry, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+		result[i].Request = proto.Clone(entry.Request).(*email_client_pb.SendEmailRequest)
+	}
+	return result
+}
+
+// findEntry 返回指定ID的邮件在列表中的下标，不存在时返回 -1
+func findEntry(entries []*Entry, id string) int {
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeEntry 从列表中删除指定ID的邮件
+func removeEntry(entries []*Entry, id string) []*Entry {
+	if i := findEntry(entries, id); i >= 0 {
+		return append(entries[:i], entries[i+1:]...)
+	}
+	return entries
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// 附件大小的默认限制
+const (
+	DefaultMaxAttachmentSize      int64 = 10 << 20 // 单个附件最大 10 MB
+	DefaultMaxTotalAttachmentSize int64 = 25 << 20 // 一封邮件所有附件合计最大 25 MB
+)
+
+// AttachmentTooLargeError 表示附件超出了大小限制
+type AttachmentTooLargeError struct {
+	Name  string // 附件文件名，超出总大小限制时为空
+	Limit int64  // 超出的限制值(字节)
+}
+
+// Error 实现 error 接口
+func (e *AttachmentTooLargeError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("附件总大小超出限制 (%d 字节)", e.Limit)
+	}
+	return fmt.Sprintf("附件 %s 超出大小限制 (%d 字节)", e.Name, e.Limit)
+}
+
+// AttachmentSource 定义附件数据的来源，可以是内存数据、io.Reader、fs.FS 或本地文件
+type AttachmentSource interface {
+	// Name 返回附件文件名
+	Name() string
+	// ContentType 返回附件的MIME类型，为空时根据文件名自动推断
+	ContentType() string
+	// Open 打开附件内容，调用方负责关闭
+	Open() (io.ReadCloser, error)
+}
+
+// attachmentSource 是 AttachmentSource 的通用实现
+type attachmentSource struct {
+	name        string
+	contentType string
+	open        func() (io.ReadCloser, error)
+}
+
+func (s *attachmentSource) Name() string                 { return s.name }
+func (s *attachmentSource) ContentType() string          { return s.contentType }
+func (s *attachmentSource) Open() (io.ReadCloser, error) { return s.open() }
+
+// AttachmentFromBytes 使用内存中的数据创建附件
+func AttachmentFromBytes(name string, data []byte) AttachmentSource {
+	return &attachmentSource{
+		name: name,
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+}
+
+// AttachmentFromReader 使用 io.Reader 创建附件
+// Reader 只能被读取一次，因此返回的附件来源只能使用一次。
+func AttachmentFromReader(name string, r io.Reader) AttachmentSource {
+	return &attachmentSource{
+		name: name,
+		open: func() (io.ReadCloser, error) {
+			if rc, ok := r.(io.ReadCloser); ok {
+				return rc, nil
+			}
+			return io.NopCloser(r), nil
+		},
+	}
+}
+
+// AttachmentFromFS 从 fs.FS（如 embed.FS）中读取附件，文件名取路径的最后一段
+func AttachmentFromFS(fsys fs.FS, name string) AttachmentSource {
+	return &attachmentSource{
+		name: path.Base(name),
+		open: func() (io.ReadCloser, error) {
+			return fsys.Open(name)
+		},
+	}
+}
+
+// AttachmentFromFile 从本地文件读取附件
+func AttachmentFromFile(filePath string) AttachmentSource {
+	return &attachmentSource{
+		name: filepath.Base(filePath),
+		open: func() (io.ReadCloser, error) {
+			return os.Open(filePath)
+		},
+	}
+}
+
+// AttachmentWithContentType 为附件来源指定MIME类型，覆盖自动推断的结果
+func AttachmentWithContentType(src AttachmentSource, contentType string) AttachmentSource {
+	return &attachmentSource{
+		name:        src.Name(),
+		contentType: contentType,
+		open:        src.Open,
+	}
+}
+
+// readAttachment 读取附件来源的内容，超过 limit 字节时返回 AttachmentTooLargeError
+func readAttachment(src AttachmentSource, limit int64) (*email_client_pb.Attachment, error) {
+	rc, err := src.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	reader := io.Reader(rc)
+	if limit > 0 {
+		// 多读一个字节用于判断是否超出限制
+		reader = io.LimitReader(rc, limit+1)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, &AttachmentTooLargeError{Name: src.Name(), Limit: limit}
+	}
+
+	contentType := src.ContentType()
+	if contentType == "" {
+		contentType = DetectContentType(src.Name(), content)
+	}
+
+	return &email_client_pb.Attachment{
+		Filename:    src.Name(),
+		Content:     content,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc/codes"
+)
+
+// BatchResult 是批量发送邮件的结果
+// 内嵌的 SendEmailsResponse 保持原有字段的访问方式，Results 总是与请求中的邮件一一对应。
+type BatchResult struct {
+	*email_client_pb.SendEmailsResponse
+
+	// Request 是产生该结果的批量发送请求
+	Request *email_client_pb.SendEmailsRequest
+
+	client *EmailServiceClient
+}
+
+// newBatchResult 创建批量发送结果，服务端未返回逐封结果时根据旧字段补全
+func newBatchResult(c *EmailServiceClient, req *email_client_pb.SendEmailsRequest, resp *email_client_pb.SendEmailsResponse) *BatchResult {
+	if len(resp.GetResults()) == 0 && len(req.GetEmails()) > 0 {
+		resp.Results = synthesizeResults(req, resp)
+	}
+	return &BatchResult{
+		SendEmailsResponse: resp,
+		Request:            req,
+		client:             c,
+	}
+}
+
+// synthesizeResults 为不支持逐封结果的旧版服务端生成发送结果
+// 旧版服务端只返回整体是否成功，因此失败时所有邮件都被视为发送失败。
+func synthesizeResults(req *email_client_pb.SendEmailsRequest, resp *email_client_pb.SendEmailsResponse) []*email_client_pb.SendResult {
+	emailIDs := resp.GetEmailIds()
+	results := make([]*email_client_pb.SendResult, len(req.GetEmails()))
+	for i := range results {
+		result := &email_client_pb.SendResult{Index: int32(i)}
+		if resp.GetSuccess() {
+			if len(emailIDs) == len(results) {
+				result.EmailId = emailIDs[i]
+			}
+		} else {
+			result.Code = int32(codes.Unknown)
+			result.ErrorMessage = resp.GetMessage()
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// Succeeded 返回发送成功的邮件结果
+func (r *BatchResult) Succeeded() []*email_client_pb.SendResult {
+	var succeeded []*email_client_pb.SendResult
+	for _, result := range r.GetResults() {
+		if codes.Code(result.GetCode()) == codes.OK {
+			succeeded = append(succeeded, result)
+		}
+	}
+	return succeeded
+}
+
+// Failed 返回发送失败的邮件结果
+func (r *BatchResult) Failed() []*email_client_pb.SendResult {
+	var failed []*email_client_pb.SendResult
+	for _, result := range r.GetResults() {
+		if codes.Code(result.GetCode()) != codes.OK {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// FailedEmails 返回发送失败的邮件，顺序与 Failed 一致
+func (r *BatchResult) FailedEmails() []*email_client_pb.Email {
+	emails := r.Request.GetEmails()
+	var failed []*email_client_pb.Email
+	for _, result := range r.Failed() {
+		if i := int(result.GetIndex()); i >= 0 && i < len(emails) {
+			failed = append(failed, emails[i])
+		}
+	}
+	return failed
+}
+
+// RetryFailed 重新发送失败的邮件，使用与原请求相同的配置
+// 返回结果中的下标对应重试请求（即 FailedEmails 的顺序），而不是原请求。没有失败的邮件时返回 nil。
+func (r *BatchResult) RetryFailed(ctx context.Context) (*BatchResult, error) {
+	emails := r.FailedEmails()
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	return r.client.SendEmails(ctx, &email_client_pb.SendEmailsRequest{
+		Emails:   emails,
+		ConfigId: r.Request.GetConfigId(),
+	})
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/email_client/client/middleware"
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// 批量发送的默认配置
+const (
+	DefaultBulkChunkSize   = 100     // 每个分块最多包含的邮件数
+	DefaultBulkChunkBytes  = 3 << 20 // 每个分块最大字节数，低于 gRPC 默认 4 MB 的消息大小限制
+	DefaultBulkConcurrency = 4       // 同时发送的分块数
+)
+
+// BulkProgress 描述批量发送的进度
+type BulkProgress struct {
+	ChunksDone  int // 已完成的分块数
+	ChunksTotal int // 分块总数
+	Sent        int // 发送成功的邮件数
+	Failed      int // 发送失败的邮件数
+	Total       int // 邮件总数
+}
+
+// BulkOption 定义批量发送配置选项的函数类型
+type BulkOption func(*BulkSender)
+
+// WithChunkSize 设置每个分块最多包含的邮件数
+func WithChunkSize(size int) BulkOption {
+	return func(s *BulkSender) {
+		if size > 0 {
+			s.chunkSize = size
+		}
+	}
+}
+
+// WithChunkBytes 设置每个分块序列化后的最大字节数，单封邮件超过该大小时单独作为一个分块
+func WithChunkBytes(size int) BulkOption {
+	return func(s *BulkSender) {
+		if size > 0 {
+			s.chunkBytes = size
+		}
+	}
+}
+
+// WithConcurrency 设置同时发送的分块数
+func WithConcurrency(n int) BulkOption {
+	return func(s *BulkSender) {
+		if n > 0 {
+			s.concurrency = n
+		}
+	}
+}
+
+// WithBulkRateLimiter 设置批量发送使用的速率限制器，每个分块发送前等待可用令牌
+// 令牌由客户端拦截器链中的速率限制拦截器消耗，因此应传入同一个速率限制器，EmailClient.BulkSender 会自动设置。
+func WithBulkRateLimiter(rl *middleware.RateLimiter) BulkOption {
+	return func(s *BulkSender) {
+		s.rateLimiter = rl
+	}
+}
+
+// WithProgress 设置进度回调，每个分块完成后调用一次，回调不会被并发调用
+func WithProgress(fn func(BulkProgress)) BulkOption {
+	return func(s *BulkSender) {
+		s.progress = fn
+	}
+}
+
+// BulkSender 将大量邮件拆分为多个分块，并发调用 SendEmails 发送
+type BulkSender struct {
+	client      *EmailServiceClient
+	chunkSize   int
+	chunkBytes  int
+	concurrency int
+	rateLimiter *middleware.RateLimiter
+	progress    func(BulkProgress)
+}
+
+// bulkChunk 是一个待发送的分块，offset 为分块第一封邮件在原列表中的下标
+type bulkChunk struct {
+	offset int
+	emails []*email_client_pb.Email
+}
+
+// NewBulkSender 创建一个使用指定邮件服务客户端的批量发送器
+func NewBulkSender(client *EmailServiceClient, opts ...BulkOption) *BulkSender {
+	s := &BulkSender{
+		client:      client,
+		chunkSize:   DefaultBulkChunkSize,
+		chunkBytes:  DefaultBulkChunkBytes,
+		concurrency: DefaultBulkConcurrency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Send 分块发送邮件并汇总每封邮件的发送结果
+// 返回结果中的下标对应 emails 中的位置。某个分块请求失败时，该分块中的邮件都会被标记为失败，
+// 其余分块继续发送；ctx 被取消时，未发送的邮件被标记为已取消，并同时返回 ctx 的错误。
+func (s *BulkSender) Send(ctx context.Context, configID string, emails []*email_client_pb.Email) (*BatchResult, error) {
+	if configID == "" {
+		return nil, ErrEmptyConfigID
+	}
+
+	chunks := s.split(emails)
+	// 同一次批量发送中各分块的幂等键由同一个前缀和分块位置组成
+	keyPrefix := NewIdempotencyKey()
+	results := make([]*email_client_pb.SendResult, len(emails))
+	progress := BulkProgress{ChunksTotal: len(chunks), Total: len(emails)}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, s.concurrency)
+	)
+
+	for _, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(chunk bulkChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			chunkResults := s.sendChunk(ctx, configID, fmt.Sprintf("%s-%d", keyPrefix, chunk.offset), chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, result := range chunkResults {
+				results[result.GetIndex()] = result
+				if codes.Code(result.GetCode()) == codes.OK {
+					progress.Sent++
+				} else {
+					progress.Failed++
+				}
+			}
+			progress.ChunksDone++
+			if s.progress != nil {
+				s.progress(progress)
+			}
+		}(chunk)
+	}
+	wg.Wait()
+
+	// 因上下文取消而未发送的邮件
+	for i, result := range results {
+		if result == nil {
+			results[i] = &email_client_pb.SendResult{
+				Index:        int32(i),
+				Code:         int32(codes.Canceled),
+				ErrorMessage: "批量发送已取消",
+			}
+		}
+	}
+
+	resp := &email_client_pb.SendEmailsResponse{Success: true, Results: results}
+	for _, result := range results {
+		if codes.Code(result.GetCode()) == codes.OK {
+			resp.EmailIds = append(resp.EmailIds, result.GetEmailId())
+		} else {
+			resp.Success = false
+		}
+	}
+	resp.Message = fmt.Sprintf("共 %d 封邮件，成功 %d 封，失败 %d 封", len(emails), len(resp.EmailIds), len(emails)-len(resp.EmailIds))
+
+	batch := &BatchResult{
+		SendEmailsResponse: resp,
+		Request:            &email_client_pb.SendEmailsRequest{Emails: emails, ConfigId: configID},
+		client:             s.client,
+	}
+	return batch, ctx.Err()
+}
+
+// split 按邮件数和序列化后的字节数将邮件拆分为分块
+func (s *BulkSender) split(emails []*email_client_pb.Email) []bulkChunk {
+	var (
+		chunks []bulkChunk
+		cur    bulkChunk
+		size   int
+	)
+	for i, email := range emails {
+		// 每封邮件在 SendEmailsRequest 中还会占用若干字节的字段标签和长度前缀
+		emailSize := proto.Size(email) + 8
+		if len(cur.emails) > 0 && (len(cur.emails) >= s.chunkSize || size+emailSize > s.chunkBytes) {
+			chunks = append(chunks, cur)
+			cur, size = bulkChunk{}, 0
+		}
+		if len(cur.emails) == 0 {
+			cur.offset = i
+		}
+		cur.emails = append(cur.emails, email)
+		size += emailSize
+	}
+	if len(cur.emails) > 0 {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
+
+// sendChunk 发送一个分块，返回的结果下标已转换为原列表中的下标
+func (s *BulkSender) sendChunk(ctx context.Context, configID, idempotencyKey string, chunk bulkChunk) []*email_client_pb.SendResult {
+	req := &email_client_pb.SendEmailsRequest{Emails: chunk.emails, ConfigId: configID, IdempotencyKey: idempotencyKey}
+
+	var (
+		batch *BatchResult
+		err   error
+	)
+	for {
+		if err = s.waitForToken(ctx); err != nil {
+			break
+		}
+		batch, err = s.client.SendEmails(ctx, req)
+		// 与其他分块争抢令牌失败时继续等待，而不是将整个分块标记为失败
+		var rateErr *middleware.RateLimitExceededError
+		if s.rateLimiter == nil || !errors.As(err, &rateErr) {
+			break
+		}
+	}
+
+	results := make([]*email_client_pb.SendResult, len(chunk.emails))
+	if err != nil {
+		if s.client.debug {
+			log.Printf("[WARN] BulkSender: 分块 [%d, %d) 发送失败: %v", chunk.offset, chunk.offset+len(chunk.emails), err)
+		}
+		for i := range results {
+			results[i] = &email_client_pb.SendResult{
+				Index:        int32(chunk.offset + i),
+				Code:         int32(errorCode(err)),
+				ErrorMessage: err.Error(),
+			}
+		}
+		return results
+	}
+
+	for i := range results {
+		results[i] = &email_client_pb.SendResult{
+			Index:        int32(chunk.offset + i),
+			Code:         int32(codes.Unknown),
+			ErrorMessage: "服务端未返回该邮件的发送结果",
+		}
+	}
+	for _, result := range batch.GetResults() {
+		if i := int(result.GetIndex()); i >= 0 && i < len(results) {
+			results[i] = &email_client_pb.SendResult{
+				Index:        int32(chunk.offset + i),
+				EmailId:      result.GetEmailId(),
+				Code:         result.GetCode(),
+				ErrorMessage: result.GetErrorMessage(),
+			}
+		}
+	}
+	return results
+}
+
+// waitForToken 等待速率限制器中有可用令牌，令牌由发送时的速率限制拦截器消耗
+func (s *BulkSender) waitForToken(ctx context.Context) error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.rateLimiter.GetAvailableTokens() < 1 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// errorCode 返回错误对应的 gRPC 状态码，上下文取消和超时分别对应 Canceled 和 DeadlineExceeded
+func errorCode(err error) codes.Code {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Code()
+	}
+	var recipientErr *InvalidRecipientError
+	if errors.As(err, &recipientErr) || errors.Is(err, ErrTooManyRecipients) {
+		return codes.InvalidArgument
+	}
+	if errors.Is(err, ErrAllRecipientsSuppressed) {
+		return codes.FailedPrecondition
+	}
+	if errors.Is(err, ErrSuppressionListUnavailable) {
+		return codes.Unavailable
+	}
+	return status.Code(err)
+}
@@ -11,14 +11,14 @@ import (
 // ConfigServiceClient 封装了与邮件配置服务交互的 gRPC 客户端。
 type ConfigServiceClient struct {
 	client          email_client_pb.EmailConfigServiceClient
-	conn            *grpc.ClientConn
+	conn            grpc.ClientConnInterface
 	requestTimeout  time.Duration
 	defaultPageSize int32
 	debug           bool
 }
 
 // NewConfigServiceClient 创建一个使用已存在连接的 ConfigServiceClient 实例。
-func NewConfigServiceClient(conn *grpc.ClientConn, requestTimeout time.Duration, defaultPageSize int32, debug bool) *ConfigServiceClient {
+func NewConfigServiceClient(conn grpc.ClientConnInterface, requestTimeout time.Duration, defaultPageSize int32, debug bool) *ConfigServiceClient {
 	// 创建 gRPC 存根
 	grpcClient := email_client_pb.NewEmailConfigServiceClient(conn)
 
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// defaultContentType 无法识别类型时使用的MIME类型
+const defaultContentType = "application/octet-stream"
+
+// sniffLen http.DetectContentType 最多检查的字节数
+const sniffLen = 512
+
+// builtinContentTypes 常见附件扩展名对应的MIME类型
+// 系统的 mime.types 在不同平台上差异较大，这里的映射优先于 mime.TypeByExtension，保证结果一致。
+var builtinContentTypes = map[string]string{
+	// 图片
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".bmp":  "image/bmp",
+	".svg":  "image/svg+xml",
+	".ico":  "image/vnd.microsoft.icon",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".heic": "image/heic",
+
+	// 文本与数据
+	".txt":  "text/plain",
+	".html": "text/html",
+	".htm":  "text/html",
+	".css":  "text/css",
+	".csv":  "text/csv",
+	".md":   "text/markdown",
+	".ics":  "text/calendar",
+	".vcf":  "text/vcard",
+	".json": "application/json",
+	".xml":  "application/xml",
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+
+	// 文档
+	".pdf":  "application/pdf",
+	".rtf":  "application/rtf",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".ods":  "application/vnd.oasis.opendocument.spreadsheet",
+	".odp":  "application/vnd.oasis.opendocument.presentation",
+	".epub": "application/epub+zip",
+
+	// 压缩包
+	".zip": "application/zip",
+	".gz":  "application/gzip",
+	".tar": "application/x-tar",
+	".7z":  "application/x-7z-compressed",
+	".rar": "application/vnd.rar",
+
+	// 音视频
+	".mp3":  "audio/mpeg",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".m4a":  "audio/mp4",
+	".mp4":  "video/mp4",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".avi":  "video/x-msvideo",
+
+	// 邮件
+	".eml": "message/rfc822",
+}
+
+// customContentTypes 用户注册的MIME类型，优先级最高
+var (
+	customContentTypes   = make(map[string]string)
+	customContentTypesMu sync.RWMutex
+)
+
+// RegisterContentType 注册或覆盖扩展名对应的MIME类型，扩展名不区分大小写，可以省略开头的点
+func RegisterContentType(ext, contentType string) error {
+	ext = normalizeExt(ext)
+	if ext == "" {
+		return fmt.Errorf("扩展名不能为空")
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return fmt.Errorf("无效的MIME类型 %q: %w", contentType, err)
+	}
+
+	customContentTypesMu.Lock()
+	defer customContentTypesMu.Unlock()
+	customContentTypes[ext] = contentType
+	return nil
+}
+
+// DetectContentType 推断附件的MIME类型
+// 依次查找：用户注册的类型、内置映射、mime.TypeByExtension，扩展名无法识别时通过
+// http.DetectContentType 嗅探内容的前 512 个字节，仍无法识别时返回 application/octet-stream。
+// head 为附件内容的开头部分，可以为 nil。
+func DetectContentType(filename string, head []byte) string {
+	ext := normalizeExt(filepath.Ext(filename))
+	if ext != "" {
+		customContentTypesMu.RLock()
+		contentType, ok := customContentTypes[ext]
+		customContentTypesMu.RUnlock()
+		if ok {
+			return contentType
+		}
+		if contentType, ok := builtinContentTypes[ext]; ok {
+			return contentType
+		}
+		if contentType := mime.TypeByExtension(ext); contentType != "" {
+			return contentType
+		}
+	}
+
+	if len(head) > 0 {
+		if len(head) > sniffLen {
+			head = head[:sniffLen]
+		}
+		return http.DetectContentType(head)
+	}
+
+	return defaultContentType
+}
+
+// normalizeExt 将扩展名转换为小写并补全开头的点
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext == "" || ext == "." {
+		return ""
+	}
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
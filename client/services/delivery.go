@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// GetEmail 按邮件ID获取单封已发送邮件，emailID 为 SendEmailResponse 或 SendResult 中返回的邮件ID
+func (c *EmailServiceClient) GetEmail(ctx context.Context, emailID string) (*email_client_pb.GetEmailResponse, error) {
+	if strings.TrimSpace(emailID) == "" {
+		return nil, ErrEmptyEmailID
+	}
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.GetEmail(ctx, &email_client_pb.GetEmailRequest{EmailId: emailID})
+}
+
+// GetDeliveryStatus 按邮件ID获取投递状态，响应中包含每个收件人的状态和按时间排列的SMTP响应
+func (c *EmailServiceClient) GetDeliveryStatus(ctx context.Context, emailID string) (*email_client_pb.GetDeliveryStatusResponse, error) {
+	if strings.TrimSpace(emailID) == "" {
+		return nil, ErrEmptyEmailID
+	}
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.GetDeliveryStatus(ctx, &email_client_pb.GetDeliveryStatusRequest{EmailId: emailID})
+}
+
+// IsFinalDeliveryState 判断投递状态是否为最终状态，最终状态不会再发生变化
+func IsFinalDeliveryState(state email_client_pb.DeliveryState) bool {
+	switch state {
+	case email_client_pb.DeliveryState_DELIVERY_STATE_DELIVERED,
+		email_client_pb.DeliveryState_DELIVERY_STATE_BOUNCED,
+		email_client_pb.DeliveryState_DELIVERY_STATE_FAILED:
+		return true
+	default:
+		return false
+	}
+}
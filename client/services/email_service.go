@@ -2,8 +2,7 @@ package services
 
 import (
 	"context"
-	"os"
-	"path/filepath"
+	"fmt"
 	"time"
 
 	"github.com/iwen-conf/email_client/proto/email_client_pb"
@@ -13,9 +12,12 @@ import (
 // EmailServiceClient 封装了与邮件服务交互的 gRPC 客户端。
 type EmailServiceClient struct {
 	client          email_client_pb.EmailServiceClient
-	conn            *grpc.ClientConn
+	conn            grpc.ClientConnInterface
 	requestTimeout  time.Duration
 	defaultPageSize int32
+	uploadChunkSize int
+	maxRecipients   int
+	suppression     *SuppressionCache
 	debug           bool
 }
 
@@ -26,7 +28,7 @@ const (
 )
 
 // NewEmailServiceClient 创建一个使用已存在连接的 EmailServiceClient 实例。
-func NewEmailServiceClient(conn *grpc.ClientConn, requestTimeout time.Duration, defaultPageSize int32, debug bool) *EmailServiceClient {
+func NewEmailServiceClient(conn grpc.ClientConnInterface, requestTimeout time.Duration, defaultPageSize int32, debug bool) *EmailServiceClient {
 	// 创建 gRPC 存根
 	grpcClient := email_client_pb.NewEmailServiceClient(conn)
 
@@ -35,6 +37,8 @@ func NewEmailServiceClient(conn *grpc.ClientConn, requestTimeout time.Duration,
 		conn:            conn,
 		requestTimeout:  requestTimeout,
 		defaultPageSize: defaultPageSize,
+		uploadChunkSize: DefaultUploadChunkSize,
+		maxRecipients:   DefaultMaxRecipients,
 		debug:           debug,
 	}
 }
@@ -110,25 +114,86 @@ func (c *EmailServiceClient) GetTestEmails(ctx context.Context, cursor string, l
 }
 
 // SendEmail 调用 gRPC 服务发送单封邮件。
+// 请求未设置幂等键时自动生成一个，重试拦截器重发同一请求时幂等键保持不变。
+// 发送前会校验并规范化收件人地址，地址不合法时返回 *InvalidRecipientError，不会发起请求。
+// 规范化在请求的副本上进行，调用方的请求和邮件保持不变。
+// 设置了抑制列表过滤时会移除被抑制的收件人，所有收件人都被抑制时返回 ErrAllRecipientsSuppressed。
 func (c *EmailServiceClient) SendEmail(ctx context.Context, req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+	email, err := normalizeEmailRecipients(req.GetEmail(), c.maxRecipients)
+	if err != nil {
+		return nil, err
+	}
+	if c.suppression != nil {
+		if email, _, err = c.suppression.filterEmail(ctx, email); err != nil {
+			return nil, err
+		}
+	}
+	req = shallowCopy(req)
+	req.Email = email
+
 	// 应用请求超时
 	if c.requestTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
 		defer cancel()
 	}
+	if req.GetIdempotencyKey() == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
 	return c.client.SendEmail(ctx, req)
 }
 
 // SendEmails 调用 gRPC 服务批量发送多封邮件。
-func (c *EmailServiceClient) SendEmails(ctx context.Context, req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+// 返回的 BatchResult 包含每封邮件的发送结果，可以通过 Failed 和 RetryFailed 处理部分失败。
+// 请求未设置幂等键时自动生成一个。任意一封邮件的收件人地址不合法时整批邮件都不会发送。
+// 与 SendEmail 一样，规范化和幂等键都作用于请求的副本，返回结果中的 Request 为实际发送的请求。
+// 设置了抑制列表过滤时，所有收件人都被抑制的邮件不会发送，其结果的状态码为 FailedPrecondition。
+func (c *EmailServiceClient) SendEmails(ctx context.Context, req *email_client_pb.SendEmailsRequest) (*BatchResult, error) {
+	emails := make([]*email_client_pb.Email, len(req.GetEmails()))
+	for i, email := range req.GetEmails() {
+		normalized, err := normalizeEmailRecipients(email, c.maxRecipients)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 封邮件: %w", i, err)
+		}
+		emails[i] = normalized
+	}
+	req = shallowCopy(req)
+	req.Emails = emails
+	if req.GetIdempotencyKey() == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+
+	// 过滤被抑制的收件人，过滤后的请求与原请求使用相同的幂等键
+	sendReq, indices, skipped := req, []int(nil), []*email_client_pb.SendResult(nil)
+	if c.suppression != nil {
+		var err error
+		if sendReq, indices, skipped, err = c.suppression.filterBatch(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
 	// 应用请求超时
 	if c.requestTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
 		defer cancel()
 	}
-	return c.client.SendEmails(ctx, req)
+	if len(skipped) == 0 {
+		resp, err := c.client.SendEmails(ctx, sendReq)
+		if err != nil {
+			return nil, err
+		}
+		return newBatchResult(c, req, resp), nil
+	}
+
+	var resp *email_client_pb.SendEmailsResponse
+	if len(sendReq.GetEmails()) > 0 {
+		var err error
+		if resp, err = c.client.SendEmails(ctx, sendReq); err != nil {
+			return nil, err
+		}
+	}
+	return newBatchResult(c, req, mergeSkipped(sendReq, resp, indices, skipped)), nil
 }
 
 // SendNormalEmail 发送正常业务邮件（便捷方法）
@@ -139,8 +204,9 @@ func (c *EmailServiceClient) SendNormalEmail(
 	from string,
 	to []string,
 	configID string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, nil)
+	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, nil, opts...)
 }
 
 // SendTestEmail 发送测试邮件（便捷方法）
@@ -151,8 +217,9 @@ func (c *EmailServiceClient) SendTestEmail(
 	from string,
 	to []string,
 	configID string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeTest, nil)
+	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeTest, nil, opts...)
 }
 
 // SendEmailWithAttachments 发送带附件的邮件
@@ -170,8 +237,9 @@ func (c *EmailServiceClient) SendEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, attachmentPaths)
+	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, attachmentPaths, opts...)
 }
 
 // SendEmailWithAttachment 发送带单个附件的邮件（便捷方法）
@@ -183,8 +251,9 @@ func (c *EmailServiceClient) SendEmailWithAttachment(
 	to []string,
 	configID string,
 	attachmentPath string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.SendEmailWithAttachments(ctx, title, content, from, to, configID, []string{attachmentPath})
+	return c.SendEmailWithAttachments(ctx, title, content, from, to, configID, []string{attachmentPath}, opts...)
 }
 
 // SendNormalEmailWithAttachments 发送带附件的正常业务邮件（便捷方法）
@@ -196,8 +265,9 @@ func (c *EmailServiceClient) SendNormalEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, attachmentPaths)
+	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeNormal, attachmentPaths, opts...)
 }
 
 // SendTestEmailWithAttachments 发送带附件的测试邮件（便捷方法）
@@ -209,8 +279,9 @@ func (c *EmailServiceClient) SendTestEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeTest, attachmentPaths)
+	return c.sendEmailWithType(ctx, title, content, from, to, configID, EmailTypeTest, attachmentPaths, opts...)
 }
 
 // SendHTMLEmail 发送HTML格式邮件（便捷方法）
@@ -221,8 +292,9 @@ func (c *EmailServiceClient) SendHTMLEmail(
 	from string,
 	to []string,
 	configID string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeNormal, nil)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeNormal, nil, opts...)
 }
 
 // SendNormalHTMLEmail 发送正常业务HTML邮件（便捷方法）
@@ -233,8 +305,9 @@ func (c *EmailServiceClient) SendNormalHTMLEmail(
 	from string,
 	to []string,
 	configID string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeNormal, nil)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeNormal, nil, opts...)
 }
 
 // SendTestHTMLEmail 发送测试HTML邮件（便捷方法）
@@ -245,8 +318,9 @@ func (c *EmailServiceClient) SendTestHTMLEmail(
 	from string,
 	to []string,
 	configID string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeTest, nil)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeTest, nil, opts...)
 }
 
 // SendHTMLEmailWithAttachments 发送带附件的HTML邮件（便捷方法）
@@ -258,8 +332,9 @@ func (c *EmailServiceClient) SendHTMLEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeNormal, attachmentPaths)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeNormal, attachmentPaths, opts...)
 }
 
 // SendNormalHTMLEmailWithAttachments 发送带附件的正常业务HTML邮件（便捷方法）
@@ -271,8 +346,9 @@ func (c *EmailServiceClient) SendNormalHTMLEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeNormal, attachmentPaths)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeNormal, attachmentPaths, opts...)
 }
 
 // SendTestHTMLEmailWithAttachments 发送带附件的测试HTML邮件（便捷方法）
@@ -284,8 +360,24 @@ func (c *EmailServiceClient) SendTestHTMLEmailWithAttachments(
 	to []string,
 	configID string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	return c.sendEmailWithType(ctx, title, []byte(htmlContent), from, to, configID, EmailTypeTest, attachmentPaths)
+	return c.sendHTMLEmailWithType(ctx, title, htmlContent, from, to, configID, EmailTypeTest, attachmentPaths, opts...)
+}
+
+// Send 发送由 MessageBuilder 构建的邮件，所有便捷发送方法最终都会调用此方法
+func (c *EmailServiceClient) Send(ctx context.Context, msg *Message) (*email_client_pb.SendEmailResponse, error) {
+	if msg == nil || msg.Email == nil {
+		return nil, ErrNilMessage
+	}
+
+	req := &email_client_pb.SendEmailRequest{
+		Email:          msg.Email,
+		ConfigId:       msg.ConfigID,
+		IdempotencyKey: msg.IdempotencyKey,
+	}
+
+	return c.SendEmail(ctx, req)
 }
 
 // sendEmailWithType 内部方法：发送指定类型的邮件
@@ -298,95 +390,46 @@ func (c *EmailServiceClient) sendEmailWithType(
 	configID string,
 	emailType string,
 	attachmentPaths []string,
+	opts ...SendOption,
 ) (*email_client_pb.SendEmailResponse, error) {
-	// 应用请求超时
-	if c.requestTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
-		defer cancel()
+	msg, err := newTypedMessage(title, from, to, configID, emailType, attachmentPaths, opts).
+		Content(content).
+		Build()
+	if err != nil {
+		return nil, err
 	}
-
-	// 创建邮件
-	email := &email_client_pb.Email{
-		Title:     title,
-		Content:   content,
-		From:      from,
-		To:        to,
-		EmailType: emailType, // 设置邮件类型
-	}
-
-	// 处理附件
-	if len(attachmentPaths) > 0 {
-		attachments, err := c.loadAttachments(attachmentPaths)
-		if err != nil {
-			return nil, err
-		}
-		email.Attachments = attachments
-	}
-
-	// 创建并发送请求
-	req := &email_client_pb.SendEmailRequest{
-		Email:    email,
-		ConfigId: configID,
-	}
-
-	return c.client.SendEmail(ctx, req)
+	return c.Send(ctx, msg)
 }
 
-// loadAttachments 从文件路径加载附件
-func (c *EmailServiceClient) loadAttachments(filePaths []string) ([]*email_client_pb.Attachment, error) {
-	attachments := make([]*email_client_pb.Attachment, 0, len(filePaths))
-
-	for _, path := range filePaths {
-		// 读取文件内容
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil, err
-		}
-
-		// 获取文件信息
-		fileInfo, err := os.Stat(path)
-		if err != nil {
-			return nil, err
-		}
-
-		// 创建附件对象
-		attachment := &email_client_pb.Attachment{
-			Filename:    filepath.Base(path),
-			Content:     content,
-			ContentType: getContentType(path), // 简单的MIME类型检测
-			Size:        fileInfo.Size(),
-		}
-
-		attachments = append(attachments, attachment)
+// sendHTMLEmailWithType 内部方法：发送指定类型的HTML邮件
+func (c *EmailServiceClient) sendHTMLEmailWithType(
+	ctx context.Context,
+	title string,
+	htmlContent string,
+	from string,
+	to []string,
+	configID string,
+	emailType string,
+	attachmentPaths []string,
+	opts ...SendOption,
+) (*email_client_pb.SendEmailResponse, error) {
+	msg, err := newTypedMessage(title, from, to, configID, emailType, attachmentPaths, opts).
+		HTML(htmlContent).
+		Build()
+	if err != nil {
+		return nil, err
 	}
-
-	return attachments, nil
+	return c.Send(ctx, msg)
 }
 
-// getContentType 根据文件扩展名返回MIME类型
-func getContentType(filename string) string {
-	ext := filepath.Ext(filename)
-	switch ext {
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".png":
-		return "image/png"
-	case ".gif":
-		return "image/gif"
-	case ".pdf":
-		return "application/pdf"
-	case ".txt":
-		return "text/plain"
-	case ".html":
-		return "text/html"
-	case ".doc", ".docx":
-		return "application/msword"
-	case ".xls", ".xlsx":
-		return "application/vnd.ms-excel"
-	case ".zip":
-		return "application/zip"
-	default:
-		return "application/octet-stream"
-	}
+// newTypedMessage 根据便捷方法的位置参数创建邮件构建器
+func newTypedMessage(title, from string, to []string, configID, emailType string, attachmentPaths []string, opts []SendOption) *MessageBuilder {
+	return NewMessage().
+		From(from).
+		To(to...).
+		Subject(title).
+		Attach(attachmentPaths...).
+		Type(emailType).
+		Config(configID).
+		Apply(opts...)
 }
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/iwen-conf/email_client/client/mime"
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// EMLResult 发送 .eml 邮件的结果
+type EMLResult struct {
+	*email_client_pb.SendEmailResponse
+	Issues []mime.Issue // 无法在 Email 中完整表示、已被忽略或调整的内容
+}
+
+// ParseEML 解析 .eml 邮件并构建可以发送的 Message
+// 邮件头、正文和附件按 MessageBuilder 的规则校验，不合法的自定义邮件头会被忽略并记录在返回的问题列表中。
+// opts 在邮件内容之后应用，可以补充收件人或覆盖回复地址等设置。
+func ParseEML(r io.Reader, configID string, opts ...SendOption) (*Message, []mime.Issue, error) {
+	email, issues, err := mime.ParseWithIssues(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := NewMessage().
+		From(email.GetFrom()).
+		To(email.GetTo()...).
+		Cc(email.GetCc()...).
+		Bcc(email.GetBcc()...).
+		Subject(email.GetTitle()).
+		Config(configID)
+	if email.GetReplyTo() != "" {
+		b.ReplyTo(email.GetReplyTo())
+	}
+	if email.GetTextBody() != "" {
+		b.Text(email.GetTextBody())
+	}
+	if email.GetHtmlBody() != "" {
+		b.HTML(email.GetHtmlBody())
+	}
+	if t := email.GetEmailType(); t == EmailTypeNormal || t == EmailTypeTest {
+		b.Type(t)
+	}
+
+	// 自定义邮件头按名称排序，保证问题列表的顺序稳定
+	names := make([]string, 0, len(email.GetHeaders()))
+	for name := range email.GetHeaders() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := email.GetHeaders()[name]
+		if err := validateHeader(name, value); err != nil {
+			issues = append(issues, mime.Issue{Part: name, Reason: fmt.Sprintf("无法作为自定义邮件头发送，已忽略: %v", err)})
+			continue
+		}
+		b.Header(name, value)
+	}
+
+	for _, a := range email.GetAttachments() {
+		if len(a.GetContent()) == 0 && a.GetAttachmentId() != "" {
+			b.AttachUploaded(a)
+			continue
+		}
+		src := AttachmentWithContentType(AttachmentFromBytes(a.GetFilename(), a.GetContent()), a.GetContentType())
+		if a.GetDisposition() == email_client_pb.Attachment_INLINE {
+			b.AttachInline(src, a.GetContentId())
+		} else {
+			b.AttachSource(src)
+		}
+	}
+
+	msg, err := b.Apply(opts...).Build()
+	if err != nil {
+		return nil, issues, err
+	}
+	return msg, issues, nil
+}
+
+// SendEML 解析 .eml 邮件（如桌面邮件客户端导出的邮件）并发送
+// 返回结果中的 Issues 列出了无法完整表示的内容，例如数字签名、Message-ID 或多余的正文部分。
+func (c *EmailServiceClient) SendEML(ctx context.Context, r io.Reader, configID string, opts ...SendOption) (*EMLResult, error) {
+	msg, issues, err := ParseEML(r, configID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Send(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &EMLResult{SendEmailResponse: resp, Issues: issues}, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+)
+
+// 构建和发送邮件时的常见错误
+var (
+	// ErrEmptySender 表示发件人地址为空
+	ErrEmptySender = errors.New("发件人地址不能为空")
+
+	// ErrNoRecipients 表示没有指定任何收件人
+	ErrNoRecipients = errors.New("至少需要一个收件人")
+
+	// ErrEmptyRecipient 表示收件人列表中存在空地址
+	ErrEmptyRecipient = errors.New("收件人地址不能为空")
+
+	// ErrTooManyRecipients 表示单封邮件的收件人数超过了限制
+	ErrTooManyRecipients = errors.New("收件人数超过限制")
+
+	// ErrEmptyConfigID 表示未指定邮件配置ID
+	ErrEmptyConfigID = errors.New("邮件配置ID不能为空")
+
+	// ErrInvalidEmailType 表示邮件类型不受支持
+	ErrInvalidEmailType = errors.New("不支持的邮件类型")
+
+	// ErrInvalidQuery 表示已发送邮件的查询条件不合法
+	ErrInvalidQuery = errors.New("邮件查询条件不合法")
+
+	// ErrEmptyTemplateID 表示未指定模板ID
+	ErrEmptyTemplateID = errors.New("模板ID不能为空")
+
+	// ErrNilMessage 表示待发送的邮件为空
+	ErrNilMessage = errors.New("待发送的邮件不能为空")
+
+	// ErrEmptyEmailID 表示未指定邮件ID
+	ErrEmptyEmailID = errors.New("邮件ID不能为空")
+
+	// ErrEmptyScheduleID 表示未指定计划邮件ID
+	ErrEmptyScheduleID = errors.New("计划邮件ID不能为空")
+
+	// ErrScheduledTimeInPast 表示计划发送时间早于当前时间
+	ErrScheduledTimeInPast = errors.New("计划发送时间不能早于当前时间")
+
+	// ErrSchedulingNotSupported 表示服务端不支持计划发送，邮件已被立即发送
+	ErrSchedulingNotSupported = errors.New("服务端不支持计划发送，邮件已被立即发送")
+
+	// ErrEmptyAttachmentID 表示引用的附件没有附件ID
+	ErrEmptyAttachmentID = errors.New("引用的附件缺少附件ID，请先调用 UploadAttachment 上传")
+
+	// ErrInvalidHeaderName 表示自定义邮件头名称不合法
+	ErrInvalidHeaderName = errors.New("邮件头名称不合法")
+
+	// ErrReservedHeader 表示自定义邮件头与客户端管理的标准邮件头冲突
+	ErrReservedHeader = errors.New("邮件头由客户端管理，不能自定义")
+
+	// ErrInvalidHeaderValue 表示自定义邮件头的值包含换行符等非法字符
+	ErrInvalidHeaderValue = errors.New("邮件头的值不合法")
+
+	// ErrAllRecipientsSuppressed 表示邮件的所有收件人都在抑制列表中，邮件不会被发送
+	ErrAllRecipientsSuppressed = errors.New("所有收件人都在抑制列表中")
+
+	// ErrSuppressionListUnavailable 表示抑制列表尚未成功加载，启用 WithFailClosed 时邮件不会被发送
+	ErrSuppressionListUnavailable = errors.New("抑制列表不可用")
+)
@@ -16,7 +16,7 @@ type HealthServiceClient struct {
 }
 
 // NewHealthServiceClient 创建一个新的健康检查服务客户端
-func NewHealthServiceClient(conn *grpc.ClientConn, requestTimeout time.Duration, debug bool) *HealthServiceClient {
+func NewHealthServiceClient(conn grpc.ClientConnInterface, requestTimeout time.Duration, debug bool) *HealthServiceClient {
 	return &HealthServiceClient{
 		grpcClient:     email_client_pb.NewHealthServiceClient(conn),
 		requestTimeout: requestTimeout,
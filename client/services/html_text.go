@@ -0,0 +1,176 @@
+package services
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements 在纯文本中需要独占一段的HTML元素
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true, "footer": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"table": true, "tr": true, "ul": true, "ol": true, "blockquote": true, "pre": true, "hr": true,
+}
+
+// skippedElements 内容不应出现在纯文本中的HTML元素
+var skippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "title": true, "noscript": true,
+}
+
+// HTMLToText 将HTML正文转换为可读的纯文本，用作 multipart/alternative 的纯文本部分
+// 转换会去除标签和脚本样式，保留段落、换行和列表结构，并在链接文字后附上链接地址。
+func HTMLToText(htmlContent string) string {
+	var (
+		sb       strings.Builder
+		skip     int      // 处于需要跳过的元素内部的层级
+		pre      int      // 处于 <pre> 内部的层级
+		hrefs    []string // 当前打开的 <a> 元素的链接地址
+		linkText []string // 当前打开的 <a> 元素内已输出的文字
+	)
+
+	writeText := func(text string) {
+		sb.WriteString(text)
+		for i := range linkText {
+			linkText[i] += text
+		}
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlContent))
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if skippedElements[token.Data] {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			switch token.Data {
+			case "br":
+				writeText("\n")
+			case "li":
+				writeText("\n- ")
+			case "td", "th":
+				writeText(" ")
+			case "img":
+				if alt := attr(token, "alt"); alt != "" {
+					writeText(alt)
+				}
+			case "a":
+				if tt == html.StartTagToken {
+					hrefs = append(hrefs, attr(token, "href"))
+					linkText = append(linkText, "")
+				}
+			case "pre":
+				pre++
+				writeText("\n\n")
+			default:
+				if blockElements[token.Data] {
+					writeText("\n\n")
+				}
+			}
+
+		case html.EndTagToken:
+			if skippedElements[token.Data] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			switch {
+			case token.Data == "a" && len(hrefs) > 0:
+				href, text := hrefs[len(hrefs)-1], linkText[len(linkText)-1]
+				hrefs, linkText = hrefs[:len(hrefs)-1], linkText[:len(linkText)-1]
+				if isPrintableLink(href) && strings.TrimSpace(text) != href {
+					writeText(" (" + href + ")")
+				}
+			case token.Data == "pre":
+				if pre > 0 {
+					pre--
+				}
+				writeText("\n\n")
+			case blockElements[token.Data]:
+				writeText("\n\n")
+			}
+
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				writeText(token.Data)
+			} else {
+				writeText(collapseSpaces(token.Data))
+			}
+		}
+	}
+
+	return normalizeLines(sb.String())
+}
+
+// attr 返回HTML元素指定属性的值
+func attr(token html.Token, name string) string {
+	for _, a := range token.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// isPrintableLink 判断链接地址是否值得在纯文本中展示
+func isPrintableLink(href string) bool {
+	return href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(strings.ToLower(href), "javascript:")
+}
+
+// collapseSpaces 将连续的空白字符折叠为一个空格
+func collapseSpaces(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if text != "" {
+			return " "
+		}
+		return ""
+	}
+	collapsed := strings.Join(fields, " ")
+	if strings.TrimLeft(text[:1], " \t\r\n") == "" {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text[len(text)-1:], " \t\r\n") == "" {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// normalizeLines 去除行首尾空白，并将连续的空行合并为一个
+func normalizeLines(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank && len(result) > 0 {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
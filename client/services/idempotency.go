@@ -0,0 +1,30 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewIdempotencyKey 生成一个随机的幂等键
+// 同一次逻辑发送应复用同一个幂等键，服务端据此对重试产生的重复请求去重。
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read 在支持的平台上不会返回错误
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// shallowCopy 返回消息的浅拷贝，顶层字段被复制，嵌套的消息和列表与原消息共享
+// 用于在发送前设置幂等键等字段而不修改调用方的请求；与 proto.Clone 不同，不会复制附件内容。
+func shallowCopy[M proto.Message](m M) M {
+	src := m.ProtoReflect()
+	dst := src.New()
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		dst.Set(fd, v)
+		return true
+	})
+	return dst.Interface().(M)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// imgSrcPattern 匹配 <img> 标签中的 src 属性
+var imgSrcPattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*)("([^"]*)"|'([^']*)')`)
+
+// contentIDUnsafe 匹配 Content-ID 中不允许出现的字符
+var contentIDUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// AttachInline 以内嵌方式添加附件，HTML正文中可以通过 cid:<contentID> 引用
+func (b *MessageBuilder) AttachInline(src AttachmentSource, contentID string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	if strings.TrimSpace(contentID) == "" {
+		b.err = fmt.Errorf("内嵌附件 %s 的 Content-ID 不能为空", src.Name())
+		return b
+	}
+	b.AttachSource(src)
+	if b.err != nil {
+		return b
+	}
+	attachment := b.email.Attachments[len(b.email.Attachments)-1]
+	attachment.Disposition = email_client_pb.Attachment_INLINE
+	attachment.ContentId = contentID
+	return b
+}
+
+// HTMLWithInlineImages 设置HTML正文，并将其中引用本地图片的 <img src="..."> 改写为 cid: 引用
+// 图片从 fsys 中读取并以内嵌附件的方式添加，同一图片被多次引用时只添加一次。
+// 远程地址（http:、https:、data:、cid: 等）保持不变。本地目录可以通过 os.DirFS 传入。
+func (b *MessageBuilder) HTMLWithInlineImages(htmlContent string, fsys fs.FS) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	contentIDs := make(map[string]string)
+	rewritten := imgSrcPattern.ReplaceAllStringFunc(htmlContent, func(tag string) string {
+		if b.err != nil {
+			return tag
+		}
+		m := imgSrcPattern.FindStringSubmatch(tag)
+		src := m[3]
+		quote := `"`
+		if strings.HasPrefix(m[2], "'") {
+			src, quote = m[4], "'"
+		}
+
+		name, ok := localImagePath(src)
+		if !ok {
+			return tag
+		}
+
+		contentID, seen := contentIDs[name]
+		if !seen {
+			contentID = fmt.Sprintf("%s.%d@inline", contentIDUnsafe.ReplaceAllString(path.Base(name), "_"), len(contentIDs)+1)
+			b.AttachInline(AttachmentFromFS(fsys, name), contentID)
+			if b.err != nil {
+				return tag
+			}
+			contentIDs[name] = contentID
+		}
+		return m[1] + quote + "cid:" + contentID + quote
+	})
+	if b.err != nil {
+		return b
+	}
+
+	return b.HTML(rewritten)
+}
+
+// localImagePath 判断图片地址是否为本地路径，并返回可用于 fs.FS 的路径
+func localImagePath(src string) (string, bool) {
+	src = strings.TrimSpace(src)
+	if src == "" || strings.HasPrefix(src, "//") {
+		return "", false
+	}
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "" {
+		return "", false
+	}
+	name := path.Clean(strings.TrimPrefix(u.Path, "/"))
+	if !fs.ValidPath(name) || name == "." {
+		return "", false
+	}
+	return name, true
+}
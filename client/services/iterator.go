@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"iter"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// PageOption 定义自动分页迭代器的可选参数
+type PageOption func(*pageOptions)
+
+// pageOptions 自动分页迭代器的配置
+type pageOptions struct {
+	maxItems int   // 最多返回的记录数，0 表示不限制
+	pageSize int32 // 每页记录数，0 表示使用默认分页大小
+}
+
+// WithMaxItems 设置迭代器最多返回的记录数
+func WithMaxItems(n int) PageOption {
+	return func(o *pageOptions) {
+		if n > 0 {
+			o.maxItems = n
+		}
+	}
+}
+
+// WithPageSize 设置迭代器每次请求的记录数，覆盖客户端的默认分页大小
+func WithPageSize(size int32) PageOption {
+	return func(o *pageOptions) {
+		if size > 0 {
+			o.pageSize = size
+		}
+	}
+}
+
+// pageFetcher 获取一页数据，返回记录、下一页的游标以及是否还有更多数据
+type pageFetcher[T any] func(ctx context.Context, cursor string, limit int32) ([]T, string, bool, error)
+
+// AllSentEmails 返回按页自动获取已发送邮件的迭代器，filter 为空时查询所有邮件
+// 每一页在迭代到时才请求，未设置 filter.Limit 时使用默认分页大小。出错或 ctx 被取消时迭代器返回一次错误后结束。
+//
+//	for email, err := range emailService.AllSentEmails(ctx, nil, services.WithMaxItems(500)) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *EmailServiceClient) AllSentEmails(ctx context.Context, filter *email_client_pb.GetSentEmailsRequest, opts ...PageOption) iter.Seq2[*email_client_pb.Email, error] {
+	req := &email_client_pb.GetSentEmailsRequest{}
+	if filter != nil {
+		req = proto.Clone(filter).(*email_client_pb.GetSentEmailsRequest)
+	}
+	options := newPageOptions(c.defaultPageSize, req.GetLimit(), opts)
+
+	return paginate(ctx, req.GetCursor(), options, func(ctx context.Context, cursor string, limit int32) ([]*email_client_pb.Email, string, bool, error) {
+		req.Cursor, req.Limit = cursor, limit
+		resp, err := c.GetSentEmails(ctx, req)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.GetEmails(), resp.GetNextCursor(), resp.GetHasMore(), nil
+	})
+}
+
+// AllConfigs 返回按页自动获取邮件配置的迭代器
+func (c *ConfigServiceClient) AllConfigs(ctx context.Context, opts ...PageOption) iter.Seq2[*email_client_pb.EmailConfig, error] {
+	options := newPageOptions(c.defaultPageSize, 0, opts)
+
+	return paginate(ctx, "", options, func(ctx context.Context, cursor string, limit int32) ([]*email_client_pb.EmailConfig, string, bool, error) {
+		resp, err := c.ListConfigs(ctx, &email_client_pb.ListConfigsRequest{Cursor: cursor, Limit: limit})
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.GetConfigs(), resp.GetNextCursor(), resp.GetHasMore(), nil
+	})
+}
+
+// newPageOptions 合并客户端默认分页大小、请求中的分页大小和调用方选项，后者优先
+func newPageOptions(defaultPageSize, limit int32, opts []PageOption) pageOptions {
+	options := pageOptions{pageSize: defaultPageSize}
+	if limit > 0 {
+		options.pageSize = limit
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// paginate 按游标逐页获取数据并逐条产出
+func paginate[T any](ctx context.Context, cursor string, options pageOptions, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			// 接近数量上限时只请求需要的记录数
+			limit := options.pageSize
+			if options.maxItems > 0 && (limit <= 0 || int(limit) > options.maxItems-count) {
+				limit = int32(options.maxItems - count)
+			}
+
+			items, next, hasMore, err := fetch(ctx, cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if options.maxItems > 0 && count >= options.maxItems {
+					return
+				}
+			}
+
+			// 服务端返回空页或重复的游标时停止，避免无限循环
+			if !hasMore || next == "" || next == cursor || len(items) == 0 {
+				return
+			}
+			cursor = next
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Message 表示一封构建完成、可以直接发送的邮件
+type Message struct {
+	Email          *email_client_pb.Email // 邮件内容
+	ConfigID       string                 // 使用的邮件配置ID
+	IdempotencyKey string                 // 幂等键，为空时发送时自动生成
+}
+
+// MessageBuilder 以链式调用的方式构建邮件，并在每一步校验参数
+// 第一次出现的错误会被记录下来，后续调用不再生效，由 Build 统一返回。
+type MessageBuilder struct {
+	email          *email_client_pb.Email
+	configID       string
+	idempotencyKey string
+	html           string
+	text           string
+	content        []byte
+	err            error
+
+	maxAttachmentSize      int64 // 单个附件大小限制
+	maxTotalAttachmentSize int64 // 附件总大小限制
+	totalAttachmentSize    int64 // 已添加附件的总大小
+}
+
+// NewMessage 创建一个新的邮件构建器，默认邮件类型为 EmailTypeNormal
+func NewMessage() *MessageBuilder {
+	return &MessageBuilder{
+		email: &email_client_pb.Email{
+			EmailType: EmailTypeNormal,
+		},
+		maxAttachmentSize:      DefaultMaxAttachmentSize,
+		maxTotalAttachmentSize: DefaultMaxTotalAttachmentSize,
+	}
+}
+
+// From 设置发件人地址
+func (b *MessageBuilder) From(from string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	if strings.TrimSpace(from) == "" {
+		b.err = ErrEmptySender
+		return b
+	}
+	b.email.From = from
+	return b
+}
+
+// To 添加收件人地址，可多次调用
+func (b *MessageBuilder) To(to ...string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, addr := range to {
+		if strings.TrimSpace(addr) == "" {
+			b.err = ErrEmptyRecipient
+			return b
+		}
+	}
+	b.email.To = append(b.email.To, to...)
+	return b
+}
+
+// Cc 添加抄送地址，可多次调用
+func (b *MessageBuilder) Cc(cc ...string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, addr := range cc {
+		if strings.TrimSpace(addr) == "" {
+			b.err = ErrEmptyRecipient
+			return b
+		}
+	}
+	b.email.Cc = append(b.email.Cc, cc...)
+	return b
+}
+
+// Bcc 添加密送地址，可多次调用
+func (b *MessageBuilder) Bcc(bcc ...string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, addr := range bcc {
+		if strings.TrimSpace(addr) == "" {
+			b.err = ErrEmptyRecipient
+			return b
+		}
+	}
+	b.email.Bcc = append(b.email.Bcc, bcc...)
+	return b
+}
+
+// ReplyTo 设置回复地址
+func (b *MessageBuilder) ReplyTo(replyTo string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.email.ReplyTo = replyTo
+	return b
+}
+
+// Header 添加自定义邮件头，名称会被规范化（如 list-unsubscribe -> List-Unsubscribe）
+// 发件人、收件人、标题等由客户端管理的邮件头不能通过此方法设置。
+func (b *MessageBuilder) Header(name, value string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	if err := validateHeader(name, value); err != nil {
+		b.err = err
+		return b
+	}
+	if b.email.Headers == nil {
+		b.email.Headers = make(map[string]string)
+	}
+	b.email.Headers[textproto.CanonicalMIMEHeaderKey(name)] = value
+	return b
+}
+
+// Subject 设置邮件标题
+func (b *MessageBuilder) Subject(subject string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.email.Title = subject
+	return b
+}
+
+// HTML 设置HTML格式的邮件正文，未设置纯文本正文时会自动生成纯文本版本
+func (b *MessageBuilder) HTML(html string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.html = html
+	return b
+}
+
+// Text 设置纯文本格式的邮件正文，与 HTML 同时设置时组成 multipart/alternative 邮件
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.text = text
+	return b
+}
+
+// Content 设置原始邮件正文，仅在未设置 HTML 和 Text 时使用
+func (b *MessageBuilder) Content(content []byte) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.content = content
+	return b
+}
+
+// Attach 从文件路径添加附件，文件会被立即读取
+func (b *MessageBuilder) Attach(paths ...string) *MessageBuilder {
+	for _, p := range paths {
+		b.AttachSource(AttachmentFromFile(p))
+	}
+	return b
+}
+
+// AttachSource 从附件来源添加附件，内容会被立即读取并校验大小限制
+func (b *MessageBuilder) AttachSource(sources ...AttachmentSource) *MessageBuilder {
+	for _, src := range sources {
+		if b.err != nil {
+			return b
+		}
+		attachment, err := readAttachment(src, b.maxAttachmentSize)
+		if err != nil {
+			b.err = fmt.Errorf("加载附件失败: %w", err)
+			return b
+		}
+		b.totalAttachmentSize += attachment.GetSize()
+		if b.maxTotalAttachmentSize > 0 && b.totalAttachmentSize > b.maxTotalAttachmentSize {
+			b.err = fmt.Errorf("加载附件失败: %w", &AttachmentTooLargeError{Limit: b.maxTotalAttachmentSize})
+			return b
+		}
+		b.email.Attachments = append(b.email.Attachments, attachment)
+	}
+	return b
+}
+
+// AttachmentLimits 设置单个附件和附件总大小的限制(字节)，小于等于0表示不限制
+// 需要在添加附件之前调用；通过 AttachUploaded 引用的附件不计入限制。
+func (b *MessageBuilder) AttachmentLimits(perAttachment, total int64) *MessageBuilder {
+	b.maxAttachmentSize = perAttachment
+	b.maxTotalAttachmentSize = total
+	return b
+}
+
+// AttachUploaded 引用通过 UploadAttachment 上传的附件，邮件请求中只携带附件ID
+func (b *MessageBuilder) AttachUploaded(attachments ...*email_client_pb.Attachment) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, attachment := range attachments {
+		if attachment == nil || attachment.GetAttachmentId() == "" {
+			b.err = ErrEmptyAttachmentID
+			return b
+		}
+		b.email.Attachments = append(b.email.Attachments, &email_client_pb.Attachment{
+			Filename:     attachment.GetFilename(),
+			ContentType:  attachment.GetContentType(),
+			Size:         attachment.GetSize(),
+			AttachmentId: attachment.GetAttachmentId(),
+		})
+	}
+	return b
+}
+
+// Type 设置邮件类型，仅支持 EmailTypeNormal 和 EmailTypeTest
+func (b *MessageBuilder) Type(emailType string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	if emailType != EmailTypeNormal && emailType != EmailTypeTest {
+		b.err = fmt.Errorf("%w: %q", ErrInvalidEmailType, emailType)
+		return b
+	}
+	b.email.EmailType = emailType
+	return b
+}
+
+// Config 设置发送邮件使用的配置ID
+func (b *MessageBuilder) Config(configID string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.configID = configID
+	return b
+}
+
+// IdempotencyKey 设置发送请求的幂等键，通常由业务ID生成，如 "order-12345-shipped"
+// 服务端对相同的幂等键只发送一次，未设置时每次发送自动生成新的幂等键。
+func (b *MessageBuilder) IdempotencyKey(key string) *MessageBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.idempotencyKey = strings.TrimSpace(key)
+	return b
+}
+
+// Err 返回构建过程中遇到的第一个错误
+func (b *MessageBuilder) Err() error {
+	return b.err
+}
+
+// Build 完成校验并返回构建好的邮件
+func (b *MessageBuilder) Build() (*Message, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.email.To) == 0 {
+		return nil, ErrNoRecipients
+	}
+	if strings.TrimSpace(b.configID) == "" {
+		return nil, ErrEmptyConfigID
+	}
+
+	// 返回副本，之后再修改构建器或重复调用 Build 不会影响已经返回的邮件
+	email := proto.Clone(b.email).(*email_client_pb.Email)
+
+	// 只提供HTML正文时自动生成纯文本版本，供只支持纯文本的邮件客户端阅读
+	email.HtmlBody = b.html
+	email.TextBody = b.text
+	if b.html != "" && b.text == "" {
+		email.TextBody = HTMLToText(b.html)
+	}
+
+	// 兼容字段 content 的优先级：HTML > 纯文本 > 原始内容
+	switch {
+	case b.html != "":
+		email.Content = []byte(b.html)
+	case b.text != "":
+		email.Content = []byte(b.text)
+	default:
+		email.Content = b.content
+	}
+
+	return &Message{
+		Email:          email,
+		ConfigID:       b.configID,
+		IdempotencyKey: b.idempotencyKey,
+	}, nil
+}
+
+// reservedHeaders 由 Email 的字段或服务端生成的邮件头，不允许自定义
+var reservedHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Cc":                        true,
+	"Bcc":                       true,
+	"Reply-To":                  true,
+	"Subject":                   true,
+	"Date":                      true,
+	"Message-Id":                true,
+	"Mime-Version":              true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+}
+
+// validateHeader 校验自定义邮件头的名称和值
+// 名称必须由 RFC 5322 规定的可打印 ASCII 字符（不含冒号）组成，值不能包含换行符以防止邮件头注入。
+func validateHeader(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("%w: 名称为空", ErrInvalidHeaderName)
+	}
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if ch < 33 || ch > 126 || ch == ':' {
+			return fmt.Errorf("%w: %q", ErrInvalidHeaderName, name)
+		}
+	}
+	if reservedHeaders[textproto.CanonicalMIMEHeaderKey(name)] {
+		return fmt.Errorf("%w: %s", ErrReservedHeader, name)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("%w: %s 包含换行符", ErrInvalidHeaderValue, name)
+	}
+	return nil
+}
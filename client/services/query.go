@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 查询已发送邮件时的限制
+const (
+	MaxSentEmailsPageSize = 100 // 每页最多返回的记录数
+	MaxSubjectFilterLen   = 200 // 标题过滤子串的最大长度(字符)
+)
+
+// SentEmailQuery 以链式调用的方式组合已发送邮件的查询条件，并在发起请求前校验
+// 与 MessageBuilder 一样，第一次出现的错误会被记录下来，由 Build 统一返回。
+type SentEmailQuery struct {
+	req *email_client_pb.GetSentEmailsRequest
+	err error
+}
+
+// NewSentEmailQuery 创建一个不带任何过滤条件的查询
+func NewSentEmailQuery() *SentEmailQuery {
+	return &SentEmailQuery{req: &email_client_pb.GetSentEmailsRequest{}}
+}
+
+// Type 按邮件类型过滤
+func (q *SentEmailQuery) Type(emailType string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	if emailType != EmailTypeNormal && emailType != EmailTypeTest {
+		q.err = fmt.Errorf("%w: %q", ErrInvalidEmailType, emailType)
+		return q
+	}
+	q.req.EmailType = emailType
+	return q
+}
+
+// Recipient 按收件人地址过滤，匹配收件人、抄送和密送
+func (q *SentEmailQuery) Recipient(address string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	addr, err := parseQueryAddress("收件人", address)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	q.req.Recipient = addr
+	return q
+}
+
+// Sender 按发件人地址过滤
+func (q *SentEmailQuery) Sender(address string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	addr, err := parseQueryAddress("发件人", address)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	q.req.Sender = addr
+	return q
+}
+
+// SubjectContains 按标题包含的子串过滤，不区分大小写
+func (q *SentEmailQuery) SubjectContains(substr string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	substr = strings.TrimSpace(substr)
+	if substr == "" {
+		q.err = fmt.Errorf("%w: 标题过滤条件不能为空", ErrInvalidQuery)
+		return q
+	}
+	if utf8.RuneCountInString(substr) > MaxSubjectFilterLen {
+		q.err = fmt.Errorf("%w: 标题过滤条件不能超过 %d 个字符", ErrInvalidQuery, MaxSubjectFilterLen)
+		return q
+	}
+	q.req.SubjectContains = substr
+	return q
+}
+
+// Config 按发送时使用的邮件配置ID过滤
+func (q *SentEmailQuery) Config(configID string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	if strings.TrimSpace(configID) == "" {
+		q.err = ErrEmptyConfigID
+		return q
+	}
+	q.req.ConfigId = configID
+	return q
+}
+
+// SentAfter 只查询在 t 及之后发送的邮件
+func (q *SentEmailQuery) SentAfter(t time.Time) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	q.req.SentAfter = timestamppb.New(t)
+	return q
+}
+
+// SentBefore 只查询在 t 之前发送的邮件
+func (q *SentEmailQuery) SentBefore(t time.Time) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	q.req.SentBefore = timestamppb.New(t)
+	return q
+}
+
+// SentBetween 只查询在 [from, to) 时间范围内发送的邮件
+func (q *SentEmailQuery) SentBetween(from, to time.Time) *SentEmailQuery {
+	return q.SentAfter(from).SentBefore(to)
+}
+
+// HasAttachments 按是否带附件过滤
+func (q *SentEmailQuery) HasAttachments(has bool) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	q.req.HasAttachments = proto.Bool(has)
+	return q
+}
+
+// Limit 设置每页返回的记录数，为 0 时使用客户端的默认分页大小
+func (q *SentEmailQuery) Limit(limit int32) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	if limit < 0 || limit > MaxSentEmailsPageSize {
+		q.err = fmt.Errorf("%w: 每页记录数必须在 0 到 %d 之间", ErrInvalidQuery, MaxSentEmailsPageSize)
+		return q
+	}
+	q.req.Limit = limit
+	return q
+}
+
+// Cursor 设置分页游标
+func (q *SentEmailQuery) Cursor(cursor string) *SentEmailQuery {
+	if q.err != nil {
+		return q
+	}
+	q.req.Cursor = cursor
+	return q
+}
+
+// Err 返回组合查询条件时遇到的第一个错误
+func (q *SentEmailQuery) Err() error {
+	return q.err
+}
+
+// Build 校验查询条件并生成请求，每次调用返回新的请求副本
+func (q *SentEmailQuery) Build() (*email_client_pb.GetSentEmailsRequest, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	after, before := q.req.GetSentAfter(), q.req.GetSentBefore()
+	if after != nil && before != nil && !after.AsTime().Before(before.AsTime()) {
+		return nil, fmt.Errorf("%w: 发送时间下限必须早于上限", ErrInvalidQuery)
+	}
+	return proto.Clone(q.req).(*email_client_pb.GetSentEmailsRequest), nil
+}
+
+// QuerySentEmails 校验查询条件后获取一页已发送邮件
+func (c *EmailServiceClient) QuerySentEmails(ctx context.Context, q *SentEmailQuery) (*email_client_pb.GetSentEmailsResponse, error) {
+	req, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSentEmails(ctx, req)
+}
+
+// parseQueryAddress 校验查询条件中的邮件地址，返回与发送时相同规范化形式的地址
+// 发送时收件人地址经过 NormalizeAddress 规范化，查询条件使用同样的形式才能匹配已发送的邮件。
+func parseQueryAddress(field, address string) (string, error) {
+	if strings.TrimSpace(address) == "" {
+		return "", fmt.Errorf("%w: %s地址不能为空", ErrInvalidQuery, field)
+	}
+	addr, err := NormalizeAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s地址 %q 不合法", ErrInvalidQuery, field, address)
+	}
+	return addr, nil
+}
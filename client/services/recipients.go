@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"golang.org/x/net/idna"
+)
+
+// DefaultMaxRecipients 单封邮件默认允许的最大收件人数（收件人、抄送和密送合计），
+// 与 RFC 5321 要求 SMTP 服务器至少支持的数量一致
+const DefaultMaxRecipients = 100
+
+// InvalidAddress 是一个不合法的收件人地址
+type InvalidAddress struct {
+	Field   string // 地址所在的字段：To、Cc 或 Bcc
+	Address string // 原始地址
+	Reason  string // 不合法的原因
+}
+
+// InvalidRecipientError 表示邮件中存在不合法的收件人地址，列出所有不合法的地址
+type InvalidRecipientError struct {
+	Invalid []InvalidAddress
+}
+
+// Error 实现 error 接口
+func (e *InvalidRecipientError) Error() string {
+	parts := make([]string, 0, len(e.Invalid))
+	for _, a := range e.Invalid {
+		if a.Field == "" {
+			parts = append(parts, fmt.Sprintf("%q (%s)", a.Address, a.Reason))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %q (%s)", a.Field, a.Address, a.Reason))
+		}
+	}
+	return "收件人地址不合法: " + strings.Join(parts, ", ")
+}
+
+// Addresses 返回所有不合法的原始地址
+func (e *InvalidRecipientError) Addresses() []string {
+	addrs := make([]string, 0, len(e.Invalid))
+	for _, a := range e.Invalid {
+		addrs = append(addrs, a.Address)
+	}
+	return addrs
+}
+
+// NormalizeAddress 校验并规范化一个邮件地址
+// 地址按 RFC 5322 解析，显示名称会被去掉，国际化域名转换为小写的 punycode 形式，
+// 例如 "张三 <Zhang@例子.中国>" 规范化为 "Zhang@xn--fsqu00a.xn--fiqs8s"。本地部分区分大小写，保持不变。
+// 地址不合法时返回 *InvalidRecipientError。
+func NormalizeAddress(address string) (string, error) {
+	invalid := func(reason string) error {
+		return &InvalidRecipientError{Invalid: []InvalidAddress{{Address: address, Reason: reason}}}
+	}
+
+	if strings.TrimSpace(address) == "" {
+		return "", invalid("地址为空")
+	}
+	addr, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", invalid("地址格式错误")
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	if at <= 0 || at == len(addr.Address)-1 {
+		return "", invalid("地址格式错误")
+	}
+	domain, err := idna.Lookup.ToASCII(addr.Address[at+1:])
+	if err != nil {
+		return "", invalid("域名不合法")
+	}
+	return addr.Address[:at] + "@" + domain, nil
+}
+
+// normalizeRecipients 校验并规范化收件人、抄送和密送地址，返回规范化后的地址列表
+// 重复的地址（域名不区分大小写）只保留第一次出现的位置，按收件人、抄送、密送的顺序判断。
+// 存在不合法的地址时返回列出所有不合法地址的 *InvalidRecipientError，
+// 去重后的地址数超过 limit 时返回 ErrTooManyRecipients；limit 小于等于 0 表示不限制。
+func normalizeRecipients(to, cc, bcc []string, limit int) ([]string, []string, []string, error) {
+	seen := make(map[string]bool)
+	var invalid []InvalidAddress
+	normalize := func(field string, addrs []string) []string {
+		if len(addrs) == 0 {
+			return addrs
+		}
+		normalized := make([]string, 0, len(addrs))
+		for _, address := range addrs {
+			addr, err := NormalizeAddress(address)
+			if err != nil {
+				var recipientErr *InvalidRecipientError
+				if errors.As(err, &recipientErr) {
+					for _, a := range recipientErr.Invalid {
+						a.Field = field
+						invalid = append(invalid, a)
+					}
+				}
+				continue
+			}
+			if seen[addr] {
+				continue
+			}
+			seen[addr] = true
+			normalized = append(normalized, addr)
+		}
+		return normalized
+	}
+
+	to, cc, bcc = normalize("To", to), normalize("Cc", cc), normalize("Bcc", bcc)
+	if len(invalid) > 0 {
+		return nil, nil, nil, &InvalidRecipientError{Invalid: invalid}
+	}
+	if limit > 0 && len(seen) > limit {
+		return nil, nil, nil, fmt.Errorf("%w: 共 %d 个，最多 %d 个", ErrTooManyRecipients, len(seen), limit)
+	}
+	return to, cc, bcc, nil
+}
+
+// normalizeEmailRecipients 校验并规范化邮件的收件人地址，返回使用规范化地址的邮件副本，原邮件保持不变
+func normalizeEmailRecipients(email *email_client_pb.Email, limit int) (*email_client_pb.Email, error) {
+	if email == nil {
+		return nil, nil
+	}
+	to, cc, bcc, err := normalizeRecipients(email.GetTo(), email.GetCc(), email.GetBcc(), limit)
+	if err != nil {
+		return nil, err
+	}
+	email = shallowCopy(email)
+	email.To, email.Cc, email.Bcc = to, cc, bcc
+	return email, nil
+}
+
+// SetMaxRecipients 设置单封邮件允许的最大收件人数（收件人、抄送和密送合计），小于等于0表示不限制
+func (c *EmailServiceClient) SetMaxRecipients(limit int) {
+	c.maxRecipients = limit
+}
+
+// SetMaxRecipients 设置单封模板邮件允许的最大收件人数（收件人、抄送和密送合计），小于等于0表示不限制
+func (c *TemplateServiceClient) SetMaxRecipients(limit int) {
+	c.maxRecipients = limit
+}
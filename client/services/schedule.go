@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Schedule 计划在指定时间发送邮件，返回的 EmailId 可用于 Cancel 取消发送
+// at 可以使用任意时区，例如 time.Date(2024, 5, 1, 9, 0, 0, 0, loc) 表示收件人所在时区的 09:00。
+// 服务端不支持计划发送时邮件会被立即发送，此时同时返回响应和 ErrSchedulingNotSupported。
+func (c *EmailServiceClient) Schedule(ctx context.Context, msg *Message, at time.Time) (*email_client_pb.SendEmailResponse, error) {
+	if msg == nil || msg.Email == nil {
+		return nil, ErrNilMessage
+	}
+	if at.Before(time.Now()) {
+		return nil, ErrScheduledTimeInPast
+	}
+
+	resp, err := c.SendEmail(ctx, &email_client_pb.SendEmailRequest{
+		Email:          msg.Email,
+		ConfigId:       msg.ConfigID,
+		ScheduledAt:    timestamppb.New(at),
+		IdempotencyKey: msg.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetSuccess() && !resp.GetScheduled() {
+		return resp, ErrSchedulingNotSupported
+	}
+	return resp, nil
+}
+
+// ListScheduledEmails 调用 gRPC 服务获取尚未发送的计划邮件列表。
+func (c *EmailServiceClient) ListScheduledEmails(ctx context.Context, req *email_client_pb.ListScheduledEmailsRequest) (*email_client_pb.ListScheduledEmailsResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	// 如果请求中未设置 Limit，可以使用默认值
+	if req.GetLimit() == 0 {
+		req.Limit = c.defaultPageSize
+	}
+
+	return c.client.ListScheduledEmails(ctx, req)
+}
+
+// CancelScheduledEmail 调用 gRPC 服务取消尚未发送的计划邮件。
+func (c *EmailServiceClient) CancelScheduledEmail(ctx context.Context, req *email_client_pb.CancelScheduledEmailRequest) (*email_client_pb.CancelScheduledEmailResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.CancelScheduledEmail(ctx, req)
+}
+
+// Cancel 取消尚未发送的计划邮件（便捷方法）
+func (c *EmailServiceClient) Cancel(ctx context.Context, id string) (*email_client_pb.CancelScheduledEmailResponse, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyScheduleID
+	}
+	return c.CancelScheduledEmail(ctx, &email_client_pb.CancelScheduledEmailRequest{Id: id})
+}
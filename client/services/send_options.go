@@ -0,0 +1,56 @@
+package services
+
+// SendOption 定义便捷发送方法的可选参数
+type SendOption func(*MessageBuilder)
+
+// WithCc 添加抄送地址
+func WithCc(cc ...string) SendOption {
+	return func(b *MessageBuilder) {
+		b.Cc(cc...)
+	}
+}
+
+// WithBcc 添加密送地址
+func WithBcc(bcc ...string) SendOption {
+	return func(b *MessageBuilder) {
+		b.Bcc(bcc...)
+	}
+}
+
+// WithReplyTo 设置回复地址
+func WithReplyTo(replyTo string) SendOption {
+	return func(b *MessageBuilder) {
+		b.ReplyTo(replyTo)
+	}
+}
+
+// WithHeader 添加一个自定义邮件头
+func WithHeader(name, value string) SendOption {
+	return func(b *MessageBuilder) {
+		b.Header(name, value)
+	}
+}
+
+// WithHeaders 批量添加自定义邮件头
+func WithHeaders(headers map[string]string) SendOption {
+	return func(b *MessageBuilder) {
+		for name, value := range headers {
+			b.Header(name, value)
+		}
+	}
+}
+
+// WithIdempotencyKey 设置发送请求的幂等键
+func WithIdempotencyKey(key string) SendOption {
+	return func(b *MessageBuilder) {
+		b.IdempotencyKey(key)
+	}
+}
+
+// Apply 在构建器上应用一组发送选项
+func (b *MessageBuilder) Apply(opts ...SendOption) *MessageBuilder {
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StreamResult 是流式发送中的一个结果
+// Err 不为空时表示流已异常结束，这是通道中的最后一个值。
+type StreamResult struct {
+	Event *email_client_pb.SendEmailEvent
+	Err   error
+}
+
+// SendStream 以双向流的方式逐封发送 emails 中的邮件，并通过返回的通道实时返回每封邮件的发送事件
+// 调用方在写完所有邮件后关闭 emails，所有事件返回后结果通道被关闭。事件中的 Index 为邮件在流中的序号。
+// 每封邮件发送前会像 SendEmail 一样校验并规范化收件人地址、过滤被抑制的收件人（在副本上进行），
+// 地址不合法的邮件不会发送，而是直接返回状态码为 InvalidArgument 的事件；
+// 所有收件人都被抑制的邮件同样不会发送，返回状态码为 FailedPrecondition 的事件。
+// 发送可能耗时较长，因此不会应用默认的请求超时，请通过 ctx 控制发送时间；
+// 提前停止读取结果时应取消 ctx，以释放内部的 goroutine。
+func (c *EmailServiceClient) SendStream(
+	ctx context.Context,
+	configID string,
+	emails <-chan *email_client_pb.Email,
+) (<-chan StreamResult, error) {
+	if strings.TrimSpace(configID) == "" {
+		return nil, ErrEmptyConfigID
+	}
+
+	// 接收失败时取消上下文，结束发送 goroutine
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.client.SendEmailsStream(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("创建邮件发送流失败: %w", err)
+	}
+
+	results := make(chan StreamResult)
+
+	// 地址不合法或所有收件人都被抑制的邮件不会发往服务端，因此服务端返回的序号需要转换为邮件在流中的序号
+	var (
+		mu      sync.Mutex
+		indices []int32 // 发往服务端的第 i 封邮件在流中的序号
+		wg      sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stream.CloseSend()
+		for index := int32(0); ; index++ {
+			var email *email_client_pb.Email
+			select {
+			case <-streamCtx.Done():
+				return
+			case e, ok := <-emails:
+				if !ok {
+					return
+				}
+				email = e
+			}
+
+			normalized, err := normalizeEmailRecipients(email, c.maxRecipients)
+			if err == nil && c.suppression != nil {
+				normalized, _, err = c.suppression.filterEmail(streamCtx, normalized)
+			}
+			if err != nil {
+				event := &email_client_pb.SendEmailEvent{
+					Result: &email_client_pb.SendResult{
+						Index:        index,
+						Code:         int32(errorCode(err)),
+						ErrorMessage: err.Error(),
+					},
+					Timestamp: timestamppb.Now(),
+				}
+				select {
+				case results <- StreamResult{Event: event}:
+				case <-streamCtx.Done():
+					return
+				}
+				continue
+			}
+
+			mu.Lock()
+			indices = append(indices, index)
+			mu.Unlock()
+			if err := stream.Send(&email_client_pb.SendEmailsStreamRequest{Email: normalized, ConfigId: configID}); err != nil {
+				// 发送失败的原因由 Recv 返回
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			result := StreamResult{Event: event}
+			if err != nil {
+				if c.debug {
+					log.Printf("[ERROR] EmailServiceClient.SendStream: 邮件发送流异常结束: %v", err)
+				}
+				result = StreamResult{Err: fmt.Errorf("邮件发送流异常结束: %w", err)}
+			} else if r := event.GetResult(); r != nil {
+				mu.Lock()
+				if i := int(r.GetIndex()); i >= 0 && i < len(indices) {
+					r.Index = indices[i]
+				}
+				mu.Unlock()
+			}
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultSuppressionRefreshInterval 本地抑制列表缓存的默认刷新间隔
+const DefaultSuppressionRefreshInterval = 5 * time.Minute
+
+// suppressionRetryInterval 刷新失败后最长的重试间隔
+const suppressionRetryInterval = 30 * time.Second
+
+// maxSuppressionPageSize 加载抑制列表时每页的记录数，为服务端允许的最大值
+const maxSuppressionPageSize = 1000
+
+// SuppressionCache 是抑制列表在本地的缓存，用于在发送前过滤被抑制的收件人
+// 缓存在第一次使用时以及每个刷新间隔之后从服务端重新加载完整的抑制列表。
+// 只有第一次加载会让发送等待，之后的刷新在后台进行，刷新失败时继续使用上一次加载的内容。
+// 第一次加载失败时默认不过滤任何地址并记录警告，启用 WithFailClosed 后改为拒绝发送。
+type SuppressionCache struct {
+	client          *SuppressionServiceClient
+	refreshInterval time.Duration
+	failClosed      bool
+	onSuppressed    func(email *email_client_pb.Email, removed []*email_client_pb.Suppression)
+
+	mu      sync.RWMutex
+	entries map[string]*email_client_pb.Suppression
+
+	refreshMu   sync.Mutex    // 保护以下字段
+	nextRefresh time.Time     // 下一次需要刷新的时间
+	refreshing  chan struct{} // 正在进行的后台刷新结束后关闭，没有刷新时为 nil
+	loaded      bool          // 是否成功加载过抑制列表
+	lastErr     error         // 最近一次刷新失败的原因
+}
+
+// SuppressionCacheOption 定义抑制列表缓存配置选项的函数类型
+type SuppressionCacheOption func(*SuppressionCache)
+
+// WithRefreshInterval 设置从服务端重新加载抑制列表的间隔
+func WithRefreshInterval(interval time.Duration) SuppressionCacheOption {
+	return func(s *SuppressionCache) {
+		if interval > 0 {
+			s.refreshInterval = interval
+		}
+	}
+}
+
+// WithFailClosed 设置抑制列表尚未成功加载时拒绝发送，返回 ErrSuppressionListUnavailable
+// 默认不拒绝：第一次加载失败时邮件照常发送，不过滤任何收件人。
+func WithFailClosed() SuppressionCacheOption {
+	return func(s *SuppressionCache) {
+		s.failClosed = true
+	}
+}
+
+// WithSuppressedHandler 设置收件人被过滤时的回调，email 为过滤前的邮件，removed 为被过滤的收件人对应的抑制记录
+// 所有收件人都被过滤时同样会调用。回调不应修改 email，它可能是调用方传入的邮件。
+func WithSuppressedHandler(handler func(email *email_client_pb.Email, removed []*email_client_pb.Suppression)) SuppressionCacheOption {
+	return func(s *SuppressionCache) {
+		s.onSuppressed = handler
+	}
+}
+
+// NewSuppressionCache 创建从 client 加载抑制列表的本地缓存
+func NewSuppressionCache(client *SuppressionServiceClient, opts ...SuppressionCacheOption) *SuppressionCache {
+	s := &SuppressionCache{
+		client:          client,
+		refreshInterval: DefaultSuppressionRefreshInterval,
+		entries:         make(map[string]*email_client_pb.Suppression),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Refresh 立即从服务端重新加载完整的抑制列表并等待加载完成，失败时保留原有的缓存内容
+func (s *SuppressionCache) Refresh(ctx context.Context) error {
+	err := s.load(ctx)
+	s.refreshMu.Lock()
+	s.finishRefresh(err)
+	s.refreshMu.Unlock()
+	return err
+}
+
+// load 按最大分页加载完整的抑制列表并替换缓存内容
+func (s *SuppressionCache) load(ctx context.Context) error {
+	entries := make(map[string]*email_client_pb.Suppression)
+	all := s.client.AllSuppressions(ctx, email_client_pb.SuppressionReason_SUPPRESSION_REASON_UNSPECIFIED, WithPageSize(maxSuppressionPageSize))
+	for suppression, err := range all {
+		if err != nil {
+			return err
+		}
+		entries[suppressionKey(suppression.GetAddress())] = suppression
+	}
+
+	s.mu.Lock()
+	s.entries = entries
+	s.mu.Unlock()
+	return nil
+}
+
+// finishRefresh 记录一次刷新的结果，失败时按较短的间隔重试，调用方需持有 refreshMu
+func (s *SuppressionCache) finishRefresh(err error) {
+	if err != nil {
+		s.lastErr = err
+		s.nextRefresh = time.Now().Add(min(s.refreshInterval, suppressionRetryInterval))
+		if s.client.debug {
+			log.Printf("[WARN] SuppressionCache: 刷新抑制列表失败，继续使用本地缓存: %v", err)
+		}
+		return
+	}
+	s.loaded, s.lastErr = true, nil
+	s.nextRefresh = time.Now().Add(s.refreshInterval)
+}
+
+// ensureFresh 在缓存过期时启动后台刷新
+// 已成功加载过时直接使用现有缓存；尚未加载时等待第一次加载完成，加载失败时按 WithFailClosed 的设置处理。
+func (s *SuppressionCache) ensureFresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	if s.refreshing == nil && !time.Now().Before(s.nextRefresh) {
+		// 刷新与发本次邮件的请求无关，调用方的截止时间和取消不应使刷新失败
+		done := make(chan struct{})
+		s.refreshing = done
+		go func() {
+			defer close(done)
+			err := s.load(context.WithoutCancel(ctx))
+			s.refreshMu.Lock()
+			s.refreshing = nil
+			s.finishRefresh(err)
+			s.refreshMu.Unlock()
+		}()
+	}
+	done, loaded := s.refreshing, s.loaded
+	s.refreshMu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return s.unavailable(ctx.Err())
+		}
+	}
+	s.refreshMu.Lock()
+	loaded, err := s.loaded, s.lastErr
+	s.refreshMu.Unlock()
+	if loaded {
+		return nil
+	}
+	return s.unavailable(err)
+}
+
+// unavailable 处理抑制列表尚未加载的情况，启用 WithFailClosed 时返回 ErrSuppressionListUnavailable
+func (s *SuppressionCache) unavailable(err error) error {
+	if s.failClosed {
+		return fmt.Errorf("%w: %v", ErrSuppressionListUnavailable, err)
+	}
+	log.Printf("[WARN] SuppressionCache: 抑制列表尚未加载，本次发送不过滤收件人: %v", err)
+	return nil
+}
+
+// Invalidate 使缓存过期，下一次发送时会在后台重新加载抑制列表
+func (s *SuppressionCache) Invalidate() {
+	s.refreshMu.Lock()
+	s.nextRefresh = time.Time{}
+	s.refreshMu.Unlock()
+}
+
+// Add 将抑制记录加入本地缓存，无需等待下一次刷新即可生效
+// 通过 SuppressionServiceClient.SetCache 关联的缓存会在 AddSuppression 成功后自动调用。
+func (s *SuppressionCache) Add(suppression *email_client_pb.Suppression) {
+	if suppression == nil {
+		return
+	}
+	s.mu.Lock()
+	s.entries[suppressionKey(suppression.GetAddress())] = suppression
+	s.mu.Unlock()
+}
+
+// Remove 将地址从本地缓存中移除，关联的缓存会在 RemoveSuppression 成功后自动调用
+func (s *SuppressionCache) Remove(address string) {
+	s.mu.Lock()
+	delete(s.entries, suppressionKey(address))
+	s.mu.Unlock()
+}
+
+// Lookup 在本地缓存中查找地址的抑制记录，已过期的记录视为不存在
+func (s *SuppressionCache) Lookup(address string) (*email_client_pb.Suppression, bool) {
+	s.mu.RLock()
+	suppression, ok := s.entries[suppressionKey(address)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if expiresAt := suppression.GetExpiresAt(); expiresAt != nil && !time.Now().Before(expiresAt.AsTime()) {
+		return nil, false
+	}
+	return suppression, true
+}
+
+// Len 返回本地缓存中的抑制记录数（包括已过期但尚未刷新掉的记录）
+func (s *SuppressionCache) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// filterEmail 移除邮件中被抑制的收件人，返回过滤后的邮件副本和被移除的收件人对应的抑制记录
+// 原邮件保持不变；没有收件人被抑制时直接返回原邮件。所有收件人都被抑制时返回 ErrAllRecipientsSuppressed。
+func (s *SuppressionCache) filterEmail(ctx context.Context, email *email_client_pb.Email) (*email_client_pb.Email, []*email_client_pb.Suppression, error) {
+	if email == nil {
+		return nil, nil, nil
+	}
+	if err := s.ensureFresh(ctx); err != nil {
+		return nil, nil, err
+	}
+	return s.filterLoaded(email)
+}
+
+// filterLoaded 使用当前的缓存内容过滤邮件的收件人，不检查缓存是否需要刷新
+func (s *SuppressionCache) filterLoaded(email *email_client_pb.Email) (*email_client_pb.Email, []*email_client_pb.Suppression, error) {
+	to, cc, bcc, removed, err := s.filterRecipients(email.GetTo(), email.GetCc(), email.GetBcc())
+	if len(removed) == 0 {
+		return email, nil, nil
+	}
+	if s.onSuppressed != nil {
+		s.onSuppressed(email, removed)
+	}
+	if err != nil {
+		return nil, removed, err
+	}
+	email = shallowCopy(email)
+	email.To, email.Cc, email.Bcc = to, cc, bcc
+	return email, removed, nil
+}
+
+// filterTemplated 移除模板邮件请求中被抑制的收件人，返回过滤后的请求副本，原请求保持不变
+// 回调收到的邮件只包含发件人、收件人和邮件类型。
+func (s *SuppressionCache) filterTemplated(ctx context.Context, req *email_client_pb.SendTemplatedEmailRequest) (*email_client_pb.SendTemplatedEmailRequest, error) {
+	if err := s.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+	to, cc, bcc, removed, err := s.filterRecipients(req.GetTo(), req.GetCc(), req.GetBcc())
+	if len(removed) == 0 {
+		return req, nil
+	}
+	if s.onSuppressed != nil {
+		s.onSuppressed(&email_client_pb.Email{
+			From:      req.GetFrom(),
+			To:        req.GetTo(),
+			Cc:        req.GetCc(),
+			Bcc:       req.GetBcc(),
+			EmailType: req.GetEmailType(),
+		}, removed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req = shallowCopy(req)
+	req.To, req.Cc, req.Bcc = to, cc, bcc
+	return req, nil
+}
+
+// filterRecipients 从收件人、抄送和密送地址中移除被抑制的地址，返回新的地址列表和被移除的地址对应的抑制记录
+// 所有地址都被抑制时返回 ErrAllRecipientsSuppressed。
+func (s *SuppressionCache) filterRecipients(to, cc, bcc []string) ([]string, []string, []string, []*email_client_pb.Suppression, error) {
+	var removed []*email_client_pb.Suppression
+	remaining := 0
+	filter := func(addrs []string) []string {
+		if len(addrs) == 0 {
+			return addrs
+		}
+		kept := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			if suppression, ok := s.Lookup(addr); ok {
+				removed = append(removed, suppression)
+				continue
+			}
+			kept = append(kept, addr)
+		}
+		remaining += len(kept)
+		return kept
+	}
+
+	to, cc, bcc = filter(to), filter(cc), filter(bcc)
+	if len(removed) > 0 && remaining == 0 {
+		addrs := make([]string, 0, len(removed))
+		for _, suppression := range removed {
+			addrs = append(addrs, suppression.GetAddress())
+		}
+		return nil, nil, nil, removed, fmt.Errorf("%w: %s", ErrAllRecipientsSuppressed, strings.Join(addrs, ", "))
+	}
+	return to, cc, bcc, removed, nil
+}
+
+// filterBatch 过滤批量请求中每封邮件被抑制的收件人，原请求和其中的邮件保持不变
+// 返回实际需要发送的请求、其中每封邮件在原请求中的下标，以及因所有收件人都被抑制而跳过的邮件结果。
+// 抑制列表不可用且启用了 WithFailClosed 时返回 ErrSuppressionListUnavailable。
+func (s *SuppressionCache) filterBatch(ctx context.Context, req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsRequest, []int, []*email_client_pb.SendResult, error) {
+	if err := s.ensureFresh(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		emails  []*email_client_pb.Email
+		indices []int
+		skipped []*email_client_pb.SendResult
+	)
+	for i, email := range req.GetEmails() {
+		filtered, _, err := s.filterLoaded(email)
+		if err != nil {
+			skipped = append(skipped, &email_client_pb.SendResult{
+				Index:        int32(i),
+				Code:         int32(codes.FailedPrecondition),
+				ErrorMessage: err.Error(),
+			})
+			continue
+		}
+		emails = append(emails, filtered)
+		indices = append(indices, i)
+	}
+	sendReq := shallowCopy(req)
+	sendReq.Emails = emails
+	return sendReq, indices, skipped, nil
+}
+
+// mergeSkipped 将过滤后请求的发送结果转换为原请求的下标，并加入被跳过的邮件结果
+// resp 为 nil 表示所有邮件都被跳过，没有发起请求。
+func mergeSkipped(sent *email_client_pb.SendEmailsRequest, resp *email_client_pb.SendEmailsResponse, indices []int, skipped []*email_client_pb.SendResult) *email_client_pb.SendEmailsResponse {
+	if resp == nil {
+		resp = &email_client_pb.SendEmailsResponse{Message: ErrAllRecipientsSuppressed.Error()}
+	} else if len(resp.GetResults()) == 0 && len(sent.GetEmails()) > 0 {
+		resp.Results = synthesizeResults(sent, resp)
+	}
+	for _, result := range resp.GetResults() {
+		if i := int(result.GetIndex()); i >= 0 && i < len(indices) {
+			result.Index = int32(indices[i])
+		}
+	}
+	resp.Results = append(resp.Results, skipped...)
+	sort.SliceStable(resp.Results, func(i, j int) bool { return resp.Results[i].GetIndex() < resp.Results[j].GetIndex() })
+	resp.Success = false
+	return resp
+}
+
+// suppressionKey 返回地址在抑制列表中的键
+// 大多数邮件服务商的本地部分不区分大小写，因此抑制列表按不区分大小写的规范化地址匹配。
+func suppressionKey(address string) string {
+	if addr, err := NormalizeAddress(address); err == nil {
+		address = addr
+	}
+	return strings.ToLower(address)
+}
+
+// SetSuppressionFilter 设置发送前用于过滤收件人的抑制列表缓存，为 nil 时不过滤
+// 启用后 SendEmail、SendEmails 和 SendStream（以及基于它们的 Send、Schedule、BulkSender 和发件箱）
+// 会在规范化收件人之后移除被抑制的地址。过滤在邮件的副本上进行，调用方的邮件保持不变。
+func (c *EmailServiceClient) SetSuppressionFilter(cache *SuppressionCache) {
+	c.suppression = cache
+}
+
+// SuppressionFilter 返回发送前使用的抑制列表缓存，未启用时返回 nil
+func (c *EmailServiceClient) SuppressionFilter() *SuppressionCache {
+	return c.suppression
+}
+
+// SetSuppressionFilter 设置模板邮件发送前用于过滤收件人的抑制列表缓存，为 nil 时不过滤
+func (c *TemplateServiceClient) SetSuppressionFilter(cache *SuppressionCache) {
+	c.suppression = cache
+}
+
+// SuppressionFilter 返回模板邮件发送前使用的抑制列表缓存，未启用时返回 nil
+func (c *TemplateServiceClient) SuppressionFilter() *SuppressionCache {
+	return c.suppression
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SuppressionServiceClient 封装了与抑制列表服务交互的 gRPC 客户端。
+type SuppressionServiceClient struct {
+	client          email_client_pb.SuppressionServiceClient
+	conn            grpc.ClientConnInterface
+	requestTimeout  time.Duration
+	defaultPageSize int32
+	cache           *SuppressionCache
+	debug           bool
+}
+
+// NewSuppressionServiceClient 创建一个使用已存在连接的 SuppressionServiceClient 实例。
+func NewSuppressionServiceClient(conn grpc.ClientConnInterface, requestTimeout time.Duration, defaultPageSize int32, debug bool) *SuppressionServiceClient {
+	// 创建 gRPC 存根
+	grpcClient := email_client_pb.NewSuppressionServiceClient(conn)
+
+	return &SuppressionServiceClient{
+		client:          grpcClient,
+		conn:            conn,
+		requestTimeout:  requestTimeout,
+		defaultPageSize: defaultPageSize,
+		debug:           debug,
+	}
+}
+
+// GetClient 返回底层的 email_client_pb.SuppressionServiceClient 存根。
+func (c *SuppressionServiceClient) GetClient() email_client_pb.SuppressionServiceClient {
+	return c.client
+}
+
+// SetRequestTimeout 设置默认的请求超时时间。
+func (c *SuppressionServiceClient) SetRequestTimeout(timeout time.Duration) {
+	c.requestTimeout = timeout
+}
+
+// SetDefaultPageSize 设置默认的分页大小。
+func (c *SuppressionServiceClient) SetDefaultPageSize(size int32) {
+	c.defaultPageSize = size
+}
+
+// SetCache 设置需要与抑制列表同步的本地缓存，为 nil 时不同步
+// 设置后 AddSuppression 和 RemoveSuppression 成功时会立即更新缓存，无需等待下一次刷新。
+func (c *SuppressionServiceClient) SetCache(cache *SuppressionCache) {
+	c.cache = cache
+}
+
+// Cache 返回与抑制列表同步的本地缓存，未设置时返回 nil
+func (c *SuppressionServiceClient) Cache() *SuppressionCache {
+	return c.cache
+}
+
+// AddSuppression 调用 gRPC 服务将地址加入抑制列表，地址会先被规范化。
+// 规范化作用于请求的副本，调用方的请求保持不变。
+func (c *SuppressionServiceClient) AddSuppression(ctx context.Context, req *email_client_pb.AddSuppressionRequest) (*email_client_pb.SuppressionResponse, error) {
+	addr, err := NormalizeAddress(req.GetSuppression().GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	req = shallowCopy(req)
+	req.Suppression = shallowCopy(req.GetSuppression())
+	req.Suppression.Address = addr
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	resp, err := c.client.AddSuppression(ctx, req)
+	if err == nil && resp.GetSuccess() && c.cache != nil {
+		suppression := resp.GetSuppression()
+		if suppression == nil {
+			suppression = req.GetSuppression()
+		}
+		c.cache.Add(suppression)
+	}
+	return resp, err
+}
+
+// Suppress 将地址加入抑制列表（便捷方法），expiresAt 为零值表示永久抑制
+func (c *SuppressionServiceClient) Suppress(ctx context.Context, address string, reason email_client_pb.SuppressionReason, expiresAt time.Time) (*email_client_pb.SuppressionResponse, error) {
+	suppression := &email_client_pb.Suppression{Address: address, Reason: reason}
+	if !expiresAt.IsZero() {
+		suppression.ExpiresAt = timestamppb.New(expiresAt)
+	}
+	return c.AddSuppression(ctx, &email_client_pb.AddSuppressionRequest{Suppression: suppression})
+}
+
+// RemoveSuppression 调用 gRPC 服务将地址移出抑制列表，地址会先被规范化。
+func (c *SuppressionServiceClient) RemoveSuppression(ctx context.Context, address string) (*email_client_pb.RemoveSuppressionResponse, error) {
+	addr, err := NormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	resp, err := c.client.RemoveSuppression(ctx, &email_client_pb.RemoveSuppressionRequest{Address: addr})
+	if err == nil && resp.GetSuccess() && c.cache != nil {
+		c.cache.Remove(addr)
+	}
+	return resp, err
+}
+
+// CheckSuppression 检查一组地址中哪些在抑制列表中，返回以规范化地址为键的抑制记录，未被抑制的地址不在结果中
+func (c *SuppressionServiceClient) CheckSuppression(ctx context.Context, addresses ...string) (map[string]*email_client_pb.Suppression, error) {
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		addr, err := NormalizeAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, addr)
+	}
+	if len(normalized) == 0 {
+		return map[string]*email_client_pb.Suppression{}, nil
+	}
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	resp, err := c.client.CheckSuppression(ctx, &email_client_pb.CheckSuppressionRequest{Addresses: normalized})
+	if err != nil {
+		return nil, err
+	}
+	suppressed := make(map[string]*email_client_pb.Suppression, len(resp.GetSuppressions()))
+	for _, s := range resp.GetSuppressions() {
+		suppressed[suppressionKey(s.GetAddress())] = s
+	}
+	return suppressed, nil
+}
+
+// ListSuppressions 调用 gRPC 服务获取抑制列表。
+func (c *SuppressionServiceClient) ListSuppressions(ctx context.Context, req *email_client_pb.ListSuppressionsRequest) (*email_client_pb.ListSuppressionsResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	// 如果请求中未设置 Limit，可以使用默认值
+	if req.GetLimit() == 0 {
+		req.Limit = c.defaultPageSize
+	}
+
+	return c.client.ListSuppressions(ctx, req)
+}
+
+// AllSuppressions 返回按页自动获取抑制列表的迭代器，reason 为 SUPPRESSION_REASON_UNSPECIFIED 时返回所有原因
+func (c *SuppressionServiceClient) AllSuppressions(ctx context.Context, reason email_client_pb.SuppressionReason, opts ...PageOption) iter.Seq2[*email_client_pb.Suppression, error] {
+	options := newPageOptions(c.defaultPageSize, 0, opts)
+
+	return paginate(ctx, "", options, func(ctx context.Context, cursor string, limit int32) ([]*email_client_pb.Suppression, string, bool, error) {
+		resp, err := c.ListSuppressions(ctx, &email_client_pb.ListSuppressionsRequest{Cursor: cursor, Limit: limit, Reason: reason})
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.GetSuppressions(), resp.GetNextCursor(), resp.GetHasMore(), nil
+	})
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TemplateServiceClient 封装了与邮件模板服务交互的 gRPC 客户端。
+type TemplateServiceClient struct {
+	client          email_client_pb.TemplateServiceClient
+	conn            grpc.ClientConnInterface
+	requestTimeout  time.Duration
+	defaultPageSize int32
+	maxRecipients   int
+	suppression     *SuppressionCache
+	debug           bool
+}
+
+// NewTemplateServiceClient 创建一个使用已存在连接的 TemplateServiceClient 实例。
+func NewTemplateServiceClient(conn grpc.ClientConnInterface, requestTimeout time.Duration, defaultPageSize int32, debug bool) *TemplateServiceClient {
+	// 创建 gRPC 存根
+	grpcClient := email_client_pb.NewTemplateServiceClient(conn)
+
+	return &TemplateServiceClient{
+		client:          grpcClient,
+		conn:            conn,
+		requestTimeout:  requestTimeout,
+		defaultPageSize: defaultPageSize,
+		maxRecipients:   DefaultMaxRecipients,
+		debug:           debug,
+	}
+}
+
+// GetClient 返回底层的 email_client_pb.TemplateServiceClient 存根。
+func (c *TemplateServiceClient) GetClient() email_client_pb.TemplateServiceClient {
+	return c.client
+}
+
+// SetRequestTimeout 设置默认的请求超时时间。
+func (c *TemplateServiceClient) SetRequestTimeout(timeout time.Duration) {
+	c.requestTimeout = timeout
+}
+
+// SetDefaultPageSize 设置默认的分页大小。
+func (c *TemplateServiceClient) SetDefaultPageSize(size int32) {
+	c.defaultPageSize = size
+}
+
+// CreateTemplate 调用 gRPC 服务创建新的邮件模板。
+func (c *TemplateServiceClient) CreateTemplate(ctx context.Context, req *email_client_pb.CreateTemplateRequest) (*email_client_pb.TemplateResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.CreateTemplate(ctx, req)
+}
+
+// GetTemplate 调用 gRPC 服务根据 ID 获取指定邮件模板。
+func (c *TemplateServiceClient) GetTemplate(ctx context.Context, req *email_client_pb.GetTemplateRequest) (*email_client_pb.TemplateResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.GetTemplate(ctx, req)
+}
+
+// UpdateTemplate 调用 gRPC 服务更新指定邮件模板。
+func (c *TemplateServiceClient) UpdateTemplate(ctx context.Context, req *email_client_pb.UpdateTemplateRequest) (*email_client_pb.TemplateResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.UpdateTemplate(ctx, req)
+}
+
+// DeleteTemplate 调用 gRPC 服务删除指定邮件模板。
+func (c *TemplateServiceClient) DeleteTemplate(ctx context.Context, req *email_client_pb.DeleteTemplateRequest) (*email_client_pb.DeleteTemplateResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.DeleteTemplate(ctx, req)
+}
+
+// ListTemplates 调用 gRPC 服务获取邮件模板列表。
+func (c *TemplateServiceClient) ListTemplates(ctx context.Context, req *email_client_pb.ListTemplatesRequest) (*email_client_pb.ListTemplatesResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	// 如果请求中未设置 Limit，可以使用默认值
+	if req.GetLimit() == 0 {
+		req.Limit = c.defaultPageSize
+	}
+
+	return c.client.ListTemplates(ctx, req)
+}
+
+// RenderTemplate 调用 gRPC 服务渲染模板预览。
+func (c *TemplateServiceClient) RenderTemplate(ctx context.Context, req *email_client_pb.RenderTemplateRequest) (*email_client_pb.RenderTemplateResponse, error) {
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	return c.client.RenderTemplate(ctx, req)
+}
+
+// SendTemplated 调用 gRPC 服务使用模板发送邮件。
+// 请求未设置幂等键时自动生成一个。收件人地址的规范化、抑制列表过滤和幂等键都作用于请求的副本，调用方的请求保持不变。
+func (c *TemplateServiceClient) SendTemplated(ctx context.Context, req *email_client_pb.SendTemplatedEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+	to, cc, bcc, err := normalizeRecipients(req.GetTo(), req.GetCc(), req.GetBcc(), c.maxRecipients)
+	if err != nil {
+		return nil, err
+	}
+	req = shallowCopy(req)
+	req.To, req.Cc, req.Bcc = to, cc, bcc
+	if c.suppression != nil {
+		if req, err = c.suppression.filterTemplated(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// 应用请求超时
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	if req.GetIdempotencyKey() == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+	return c.client.SendTemplatedEmail(ctx, req)
+}
+
+// PreviewTemplate 使用给定变量渲染模板预览（便捷方法）
+func (c *TemplateServiceClient) PreviewTemplate(ctx context.Context, templateID string, vars map[string]any) (*email_client_pb.RenderTemplateResponse, error) {
+	if strings.TrimSpace(templateID) == "" {
+		return nil, ErrEmptyTemplateID
+	}
+	variables, err := TemplateVariables(vars)
+	if err != nil {
+		return nil, err
+	}
+	return c.RenderTemplate(ctx, &email_client_pb.RenderTemplateRequest{
+		TemplateId: templateID,
+		Variables:  variables,
+	})
+}
+
+// SendTemplatedEmail 使用服务端模板渲染并发送正常业务邮件（便捷方法）
+// vars 中的值可以是任意可以编码为 JSON 的类型，time.Time 会被编码为 RFC 3339 字符串。
+func (c *TemplateServiceClient) SendTemplatedEmail(
+	ctx context.Context,
+	templateID string,
+	vars map[string]any,
+	to []string,
+	configID string,
+) (*email_client_pb.SendEmailResponse, error) {
+	if strings.TrimSpace(templateID) == "" {
+		return nil, ErrEmptyTemplateID
+	}
+	if len(to) == 0 {
+		return nil, ErrNoRecipients
+	}
+	for _, addr := range to {
+		if strings.TrimSpace(addr) == "" {
+			return nil, ErrEmptyRecipient
+		}
+	}
+	if strings.TrimSpace(configID) == "" {
+		return nil, ErrEmptyConfigID
+	}
+
+	variables, err := TemplateVariables(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendTemplated(ctx, &email_client_pb.SendTemplatedEmailRequest{
+		TemplateId: templateID,
+		Variables:  variables,
+		To:         to,
+		ConfigId:   configID,
+		EmailType:  EmailTypeNormal,
+	})
+}
+
+// TemplateVariables 将 Go 值转换为模板变量使用的 google.protobuf.Struct
+// 值会先编码为 JSON，因此结构体字段遵循 json 标签，time.Time 会被编码为 RFC 3339 字符串。
+func TemplateVariables(vars map[string]any) (*structpb.Struct, error) {
+	if len(vars) == 0 {
+		return &structpb.Struct{}, nil
+	}
+
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return nil, fmt.Errorf("模板变量无法编码: %w", err)
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("模板变量无法编码: %w", err)
+	}
+
+	variables, err := structpb.NewStruct(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("模板变量无法编码: %w", err)
+	}
+	return variables, nil
+}
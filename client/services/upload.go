@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// DefaultUploadChunkSize 流式上传附件时每个数据块的默认大小
+const DefaultUploadChunkSize = 256 * 1024
+
+// SetUploadChunkSize 设置流式上传附件时每个数据块的大小
+func (c *EmailServiceClient) SetUploadChunkSize(size int) {
+	if size > 0 {
+		c.uploadChunkSize = size
+	}
+}
+
+// UploadAttachment 以客户端流的方式分块上传附件，不会将整个附件读入内存
+// 返回的附件只包含附件ID和元数据，可以通过 MessageBuilder.AttachUploaded 在发送邮件时引用。
+// contentType 为空时根据文件名和内容推断；size 未知时传 0。
+// 上传大文件可能耗时较长，因此不会应用默认的请求超时，请通过 ctx 控制上传时间。
+func (c *EmailServiceClient) UploadAttachment(
+	ctx context.Context,
+	filename string,
+	contentType string,
+	size int64,
+	r io.Reader,
+) (*email_client_pb.Attachment, error) {
+	chunkSize := c.uploadChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	buf := make([]byte, chunkSize)
+
+	// 先读取第一个数据块，未指定类型时用于嗅探附件的MIME类型
+	n, readErr := io.ReadFull(r, buf)
+	if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("读取附件内容失败: %w", readErr)
+	}
+	if contentType == "" {
+		contentType = DetectContentType(filename, buf[:n])
+	}
+
+	// 任何一步失败时取消上下文，通知服务端丢弃未完成的上传
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("创建附件上传流失败: %w", err)
+	}
+
+	err = stream.Send(&email_client_pb.UploadAttachmentRequest{
+		Data: &email_client_pb.UploadAttachmentRequest_Metadata{
+			Metadata: &email_client_pb.AttachmentMetadata{
+				Filename:    filename,
+				ContentType: contentType,
+				Size:        size,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("发送附件元数据失败: %w", err)
+	}
+
+	var sent int64
+	for {
+		if n > 0 {
+			// 数据块在发送前复制一份，避免缓冲区被下一次读取覆盖
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			err = stream.Send(&email_client_pb.UploadAttachmentRequest{
+				Data: &email_client_pb.UploadAttachmentRequest_Chunk{Chunk: chunk},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("发送附件数据失败: %w", err)
+			}
+			sent += int64(n)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("读取附件内容失败: %w", readErr)
+		}
+		n, readErr = io.ReadFull(r, buf)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("上传附件失败: %w", err)
+	}
+	if !resp.GetSuccess() {
+		return nil, fmt.Errorf("上传附件失败: %s", resp.GetMessage())
+	}
+	if resp.GetSize() != 0 && resp.GetSize() != sent {
+		return nil, fmt.Errorf("上传附件大小不一致: 已发送 %d 字节，服务端接收 %d 字节", sent, resp.GetSize())
+	}
+
+	if c.debug {
+		log.Printf("[INFO] EmailServiceClient.UploadAttachment: 附件 %s 上传成功, ID=%s, 大小=%d", filename, resp.GetAttachmentId(), sent)
+	}
+
+	return &email_client_pb.Attachment{
+		Filename:     filename,
+		ContentType:  contentType,
+		Size:         sent,
+		AttachmentId: resp.GetAttachmentId(),
+	}, nil
+}
+
+// UploadFile 以流的方式上传本地文件作为附件
+func (c *EmailServiceClient) UploadFile(ctx context.Context, path string) (*email_client_pb.Attachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UploadAttachment(ctx, filepath.Base(path), "", info.Size(), file)
+}
+
+// UploadSource 以流的方式上传附件来源，适用于内存数据、io.Reader 和 fs.FS 中的文件
+func (c *EmailServiceClient) UploadSource(ctx context.Context, src AttachmentSource) (*email_client_pb.Attachment, error) {
+	rc, err := src.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return c.UploadAttachment(ctx, src.Name(), src.ContentType(), 0, rc)
+}
+
+// UploadFiles 依次上传多个本地文件，返回的附件顺序与路径顺序一致
+func (c *EmailServiceClient) UploadFiles(ctx context.Context, paths []string) ([]*email_client_pb.Attachment, error) {
+	attachments := make([]*email_client_pb.Attachment, 0, len(paths))
+	for _, path := range paths {
+		attachment, err := c.UploadFile(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("上传附件 %s 失败: %w", path, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
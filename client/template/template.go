@@ -0,0 +1,397 @@
+// Package template 在客户端渲染邮件模板，生成可以直接通过 EmailServiceClient.SendEmail 发送的邮件。
+//
+// 模板从 fs.FS 中加载，目录结构约定如下：
+//
+//	layouts/<布局>.html       HTML布局，通过 {{template "content" .}} 引用邮件正文
+//	layouts/<布局>.txt        纯文本布局
+//	partials/<片段>.html      HTML片段，通过 {{template "<片段>" .}} 引用
+//	partials/<片段>.txt       纯文本片段
+//	<模板名>/subject.txt      邮件标题
+//	<模板名>/body.html        HTML正文
+//	<模板名>/body.txt         纯文本正文
+//
+// 每个文件都可以有语言变体，如 subject.zh-CN.txt、body.en.html。渲染时按
+// zh-CN → zh → 无语言后缀 的顺序查找，每个文件单独回退。
+// HTML正文使用 html/template 渲染并自动转义，标题和纯文本正文使用 text/template 渲染。
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/iwen-conf/email_client/client/services"
+	"github.com/iwen-conf/email_client/proto/email_client_pb"
+)
+
+// 模板目录与文件名约定
+const (
+	layoutsDir  = "layouts"
+	partialsDir = "partials"
+	contentName = "content" // 布局中引用邮件正文的模板名
+
+	subjectFile = "subject"
+	bodyFile    = "body"
+	htmlExt     = ".html"
+	textExt     = ".txt"
+)
+
+var (
+	// ErrTemplateNotFound 表示模板不存在，或既没有HTML正文也没有纯文本正文
+	ErrTemplateNotFound = errors.New("邮件模板不存在")
+
+	// ErrSubjectNotFound 表示模板缺少邮件标题
+	ErrSubjectNotFound = errors.New("邮件模板缺少标题")
+
+	// ErrInvalidSubject 表示渲染后的标题包含换行符
+	ErrInvalidSubject = errors.New("邮件标题不能包含换行符")
+)
+
+// FuncMap 定义模板中可用的自定义函数，同时适用于HTML和纯文本模板
+type FuncMap map[string]any
+
+// Option 定义模板集合配置选项的函数类型
+type Option func(*Set)
+
+// WithLayout 设置邮件正文使用的布局，对应 layouts/<name>.html 和 layouts/<name>.txt
+// 某种正文格式没有对应的布局文件时，该格式的正文直接渲染，不使用布局。
+func WithLayout(name string) Option {
+	return func(s *Set) {
+		s.layout = name
+	}
+}
+
+// WithFuncs 添加模板中可用的自定义函数
+func WithFuncs(funcs FuncMap) Option {
+	return func(s *Set) {
+		for name, fn := range funcs {
+			s.funcs[name] = fn
+		}
+	}
+}
+
+// WithMissingKeyError 设置模板引用不存在的 map 键时返回错误，而不是输出零值
+func WithMissingKeyError() Option {
+	return func(s *Set) {
+		s.missingKeyError = true
+	}
+}
+
+// Set 是从 fs.FS 中加载的一组邮件模板，可以被多个 goroutine 并发使用
+// 模板在首次使用时解析，解析结果按模板名和语言缓存。
+type Set struct {
+	fsys            fs.FS
+	layout          string
+	funcs           FuncMap
+	missingKeyError bool
+
+	mu    sync.Mutex
+	cache map[string]*compiled
+}
+
+// compiled 是某个模板在某个语言下解析完成的结果
+type compiled struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+// Rendered 是渲染完成的邮件内容
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// New 创建一个从 fsys 中加载模板的模板集合，本地目录可以通过 os.DirFS 传入
+func New(fsys fs.FS, opts ...Option) *Set {
+	s := &Set{
+		fsys:  fsys,
+		funcs: make(FuncMap),
+		cache: make(map[string]*compiled),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Render 使用 data 渲染指定语言的模板，locale 为空时使用无语言后缀的模板
+func (s *Set) Render(name, locale string, data any) (*Rendered, error) {
+	c, err := s.lookup(name, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := c.subject.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("渲染模板 %s 的标题失败: %w", name, err)
+	}
+	subject := strings.TrimSpace(buf.String())
+	if strings.ContainsAny(subject, "\r\n") {
+		return nil, fmt.Errorf("模板 %s: %w", name, ErrInvalidSubject)
+	}
+
+	rendered := &Rendered{Subject: subject}
+	if c.html != nil {
+		buf.Reset()
+		if err := c.html.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("渲染模板 %s 的HTML正文失败: %w", name, err)
+		}
+		rendered.HTML = buf.String()
+	}
+	if c.text != nil {
+		buf.Reset()
+		if err := c.text.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("渲染模板 %s 的纯文本正文失败: %w", name, err)
+		}
+		rendered.Text = buf.String()
+	}
+	return rendered, nil
+}
+
+// Email 渲染模板并生成邮件，调用方还需要设置发件人和收件人
+// 模板只有HTML正文时，纯文本正文由 services.HTMLToText 自动生成。
+func (s *Set) Email(name, locale string, data any) (*email_client_pb.Email, error) {
+	rendered, err := s.Render(name, locale, data)
+	if err != nil {
+		return nil, err
+	}
+	return rendered.Email(), nil
+}
+
+// Email 使用渲染结果生成邮件，Content 与 MessageBuilder 一致，优先使用HTML正文
+func (r *Rendered) Email() *email_client_pb.Email {
+	text := r.Text
+	if text == "" && r.HTML != "" {
+		text = services.HTMLToText(r.HTML)
+	}
+	content := r.HTML
+	if content == "" {
+		content = text
+	}
+	return &email_client_pb.Email{
+		Title:     r.Subject,
+		Content:   []byte(content),
+		HtmlBody:  r.HTML,
+		TextBody:  text,
+		EmailType: services.EmailTypeNormal,
+	}
+}
+
+// lookup 返回缓存的解析结果，不存在时解析模板
+func (s *Set) lookup(name, locale string) (*compiled, error) {
+	if !fs.ValidPath(name) || name == "." || name == layoutsDir || name == partialsDir {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	locales := fallbackLocales(locale)
+	key := name + "\x00" + locales[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.cache[key]; ok {
+		return c, nil
+	}
+	c, err := s.compile(name, locales)
+	if err != nil {
+		return nil, err
+	}
+	s.cache[key] = c
+	return c, nil
+}
+
+// compile 按语言回退顺序查找并解析模板的各个文件
+func (s *Set) compile(name string, locales []string) (*compiled, error) {
+	if info, err := fs.Stat(s.fsys, name); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+
+	c := &compiled{}
+	subjectSrc, subjectPath, err := s.readVariant(name, subjectFile, textExt, locales)
+	if err != nil {
+		return nil, err
+	}
+	if subjectPath == "" {
+		return nil, fmt.Errorf("模板 %s: %w", name, ErrSubjectNotFound)
+	}
+	c.subject, err = s.newText(subjectPath).Parse(subjectSrc)
+	if err != nil {
+		return nil, fmt.Errorf("解析模板 %s 失败: %w", subjectPath, err)
+	}
+
+	htmlSrc, htmlPath, err := s.readVariant(name, bodyFile, htmlExt, locales)
+	if err != nil {
+		return nil, err
+	}
+	if htmlPath != "" {
+		if c.html, err = s.parseHTML(htmlPath, htmlSrc); err != nil {
+			return nil, err
+		}
+	}
+
+	textSrc, textPath, err := s.readVariant(name, bodyFile, textExt, locales)
+	if err != nil {
+		return nil, err
+	}
+	if textPath != "" {
+		if c.text, err = s.parseText(textPath, textSrc); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.html == nil && c.text == nil {
+		return nil, fmt.Errorf("%w: %s 没有正文", ErrTemplateNotFound, name)
+	}
+	return c, nil
+}
+
+// parseHTML 解析HTML正文，并加载HTML片段和布局
+func (s *Set) parseHTML(bodyPath, bodySrc string) (*htmltemplate.Template, error) {
+	t := htmltemplate.New(contentName).Funcs(htmltemplate.FuncMap(s.funcs))
+	if s.missingKeyError {
+		t = t.Option("missingkey=error")
+	}
+	if _, err := t.Parse(bodySrc); err != nil {
+		return nil, fmt.Errorf("解析模板 %s 失败: %w", bodyPath, err)
+	}
+
+	partials, err := s.readDir(partialsDir, htmlExt)
+	if err != nil {
+		return nil, err
+	}
+	for partialName, src := range partials {
+		if _, err := t.New(partialName).Parse(src); err != nil {
+			return nil, fmt.Errorf("解析片段 %s 失败: %w", path.Join(partialsDir, partialName+htmlExt), err)
+		}
+	}
+
+	layoutSrc, ok, err := s.readLayout(htmlExt)
+	if err != nil || !ok {
+		return t, err
+	}
+	layout, err := t.New(s.layout).Parse(layoutSrc)
+	if err != nil {
+		return nil, fmt.Errorf("解析布局 %s 失败: %w", s.layout+htmlExt, err)
+	}
+	return layout, nil
+}
+
+// parseText 解析纯文本正文，并加载纯文本片段和布局
+func (s *Set) parseText(bodyPath, bodySrc string) (*texttemplate.Template, error) {
+	t, err := s.newText(contentName).Parse(bodySrc)
+	if err != nil {
+		return nil, fmt.Errorf("解析模板 %s 失败: %w", bodyPath, err)
+	}
+
+	partials, err := s.readDir(partialsDir, textExt)
+	if err != nil {
+		return nil, err
+	}
+	for partialName, src := range partials {
+		if _, err := t.New(partialName).Parse(src); err != nil {
+			return nil, fmt.Errorf("解析片段 %s 失败: %w", path.Join(partialsDir, partialName+textExt), err)
+		}
+	}
+
+	layoutSrc, ok, err := s.readLayout(textExt)
+	if err != nil || !ok {
+		return t, err
+	}
+	layout, err := t.New(s.layout).Parse(layoutSrc)
+	if err != nil {
+		return nil, fmt.Errorf("解析布局 %s 失败: %w", s.layout+textExt, err)
+	}
+	return layout, nil
+}
+
+// newText 创建应用了自定义函数和选项的纯文本模板
+func (s *Set) newText(name string) *texttemplate.Template {
+	t := texttemplate.New(name).Funcs(texttemplate.FuncMap(s.funcs))
+	if s.missingKeyError {
+		t = t.Option("missingkey=error")
+	}
+	return t
+}
+
+// readVariant 按语言回退顺序读取 <name>/<base>.<locale><ext>，都不存在时返回空路径
+func (s *Set) readVariant(name, base, ext string, locales []string) (string, string, error) {
+	for _, locale := range locales {
+		file := base + ext
+		if locale != "" {
+			file = base + "." + locale + ext
+		}
+		p := path.Join(name, file)
+		data, err := fs.ReadFile(s.fsys, p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("读取模板 %s 失败: %w", p, err)
+		}
+		return string(data), p, nil
+	}
+	return "", "", nil
+}
+
+// readLayout 读取当前布局指定格式的文件，未设置布局或文件不存在时返回 false
+func (s *Set) readLayout(ext string) (string, bool, error) {
+	if s.layout == "" {
+		return "", false, nil
+	}
+	p := path.Join(layoutsDir, s.layout+ext)
+	data, err := fs.ReadFile(s.fsys, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("读取布局 %s 失败: %w", p, err)
+	}
+	return string(data), true, nil
+}
+
+// readDir 读取目录下指定扩展名的所有文件，返回以不含扩展名的文件名为键的内容，目录不存在时返回空
+func (s *Set) readDir(dir, ext string) (map[string]string, error) {
+	entries, err := fs.ReadDir(s.fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取目录 %s 失败: %w", dir, err)
+	}
+
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ext {
+			continue
+		}
+		p := path.Join(dir, entry.Name())
+		data, err := fs.ReadFile(s.fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件 %s 失败: %w", p, err)
+		}
+		files[strings.TrimSuffix(entry.Name(), ext)] = string(data)
+	}
+	return files, nil
+}
+
+// fallbackLocales 返回语言的回退顺序，如 zh-Hant-TW → [zh-Hant-TW zh-Hant zh ""]
+// 下划线会被规范化为连字符，最后一项总是表示无语言后缀的空字符串。
+func fallbackLocales(locale string) []string {
+	locale = strings.Trim(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	var locales []string
+	for locale != "" {
+		locales = append(locales, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return append(locales, "")
+}
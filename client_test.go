@@ -1,17 +1,41 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/iwen-conf/email_client/client"
+	"github.com/iwen-conf/email_client/client/export"
 	"github.com/iwen-conf/email_client/client/logger"
+	"github.com/iwen-conf/email_client/client/middleware"
+	emailmime "github.com/iwen-conf/email_client/client/mime"
+	"github.com/iwen-conf/email_client/client/outbox"
 	"github.com/iwen-conf/email_client/client/services"
+	emailtemplate "github.com/iwen-conf/email_client/client/template"
 	"github.com/iwen-conf/email_client/proto/email_client_pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -812,6 +836,2328 @@ func TestEmailTypeFeatures(t *testing.T) {
 	})
 }
 
+// TestMessageBuilder 测试邮件构建器的校验和构建结果
+func TestMessageBuilder(t *testing.T) {
+	t.Run("构建完整邮件", func(t *testing.T) {
+		msg, err := services.NewMessage().
+			From("sender@example.com").
+			To("a@example.com", "b@example.com").
+			Subject("月度报告").
+			HTML("<p>报告内容</p>").
+			Type(services.EmailTypeTest).
+			Config("config123").
+			Build()
+		if err != nil {
+			t.Fatalf("构建邮件失败: %v", err)
+		}
+		if msg.ConfigID != "config123" {
+			t.Errorf("期望配置ID为'config123'，得到'%s'", msg.ConfigID)
+		}
+		if len(msg.Email.To) != 2 {
+			t.Errorf("期望2个收件人，得到%d", len(msg.Email.To))
+		}
+		if msg.Email.EmailType != services.EmailTypeTest {
+			t.Errorf("期望邮件类型为'%s'，得到'%s'", services.EmailTypeTest, msg.Email.EmailType)
+		}
+		if string(msg.Email.Content) != "<p>报告内容</p>" {
+			t.Errorf("邮件内容不匹配，得到'%s'", msg.Email.Content)
+		}
+	})
+
+	t.Run("校验错误", func(t *testing.T) {
+		cases := map[string]struct {
+			builder *services.MessageBuilder
+			want    error
+		}{
+			"空发件人":   {services.NewMessage().From("").To("a@example.com").Config("c"), services.ErrEmptySender},
+			"没有收件人":  {services.NewMessage().From("s@example.com").Config("c"), services.ErrNoRecipients},
+			"空收件人":   {services.NewMessage().From("s@example.com").To("a@example.com", " ").Config("c"), services.ErrEmptyRecipient},
+			"未知邮件类型": {services.NewMessage().From("s@example.com").To("a@example.com").Type("spam").Config("c"), services.ErrInvalidEmailType},
+			"缺少配置ID": {services.NewMessage().From("s@example.com").To("a@example.com"), services.ErrEmptyConfigID},
+		}
+		for name, tc := range cases {
+			if _, err := tc.builder.Build(); !errors.Is(err, tc.want) {
+				t.Errorf("%s: 期望错误 %v，得到 %v", name, tc.want, err)
+			}
+		}
+	})
+
+	t.Run("抄送密送与自定义邮件头", func(t *testing.T) {
+		msg, err := services.NewMessage().
+			From("support@example.com").
+			To("customer@example.com").
+			Config("c").
+			Apply(
+				services.WithCc("manager@example.com"),
+				services.WithBcc("archive@example.com"),
+				services.WithReplyTo("help@example.com"),
+				services.WithHeader("x-campaign", "spring-2024"),
+			).
+			Build()
+		if err != nil {
+			t.Fatalf("构建邮件失败: %v", err)
+		}
+		if len(msg.Email.Cc) != 1 || len(msg.Email.Bcc) != 1 || msg.Email.ReplyTo != "help@example.com" {
+			t.Errorf("抄送、密送或回复地址设置错误: %v %v %q", msg.Email.Cc, msg.Email.Bcc, msg.Email.ReplyTo)
+		}
+		if msg.Email.Headers["X-Campaign"] != "spring-2024" {
+			t.Errorf("自定义邮件头应被规范化为 X-Campaign，得到 %v", msg.Email.Headers)
+		}
+	})
+
+	t.Run("非法邮件头", func(t *testing.T) {
+		cases := map[string]struct {
+			name, value string
+			want        error
+		}{
+			"名称包含冒号": {"X-Bad:Name", "v", services.ErrInvalidHeaderName},
+			"名称包含空格": {"X Bad", "v", services.ErrInvalidHeaderName},
+			"保留邮件头":  {"subject", "v", services.ErrReservedHeader},
+			"值包含换行":  {"X-Note", "a\r\nBcc: evil@example.com", services.ErrInvalidHeaderValue},
+		}
+		for name, tc := range cases {
+			err := services.NewMessage().Header(tc.name, tc.value).Err()
+			if !errors.Is(err, tc.want) {
+				t.Errorf("%s: 期望错误 %v，得到 %v", name, tc.want, err)
+			}
+		}
+	})
+
+	t.Run("附件不存在", func(t *testing.T) {
+		_, err := services.NewMessage().
+			From("s@example.com").
+			To("a@example.com").
+			Attach(filepath.Join(t.TempDir(), "missing.pdf")).
+			Config("c").
+			Build()
+		if err == nil {
+			t.Errorf("附件不存在时应该返回错误")
+		}
+	})
+	t.Run("构建结果互不影响", func(t *testing.T) {
+		b := services.NewMessage().From("s@example.com").To("a@example.com").Subject("第一封").Config("c")
+		first, err := b.Build()
+		if err != nil {
+			t.Fatalf("构建失败: %v", err)
+		}
+		second, err := b.Subject("第二封").To("b@example.com").Build()
+		if err != nil {
+			t.Fatalf("构建失败: %v", err)
+		}
+		if first.Email == second.Email || first.Email.GetTitle() != "第一封" || len(first.Email.GetTo()) != 1 {
+			t.Errorf("修改构建器后已返回的邮件被改变: %v", first.Email)
+		}
+		if second.Email.GetTitle() != "第二封" || len(second.Email.GetTo()) != 2 {
+			t.Errorf("第二次构建结果错误: %v", second.Email)
+		}
+	})
+}
+
+// TestHTMLToText 测试HTML正文到纯文本的转换
+func TestHTMLToText(t *testing.T) {
+	htmlContent := `<html><head><title>通知</title><style>p { color: red; }</style></head>
+	<body>
+		<h1>订单已发货</h1>
+		<p>您好，您的订单 <strong>#12345</strong>   已发货。<br>请注意查收。</p>
+		<ul><li>商品A</li><li>商品B</li></ul>
+		<p><a href="https://example.com/track">查看物流</a></p>
+		<script>alert("x")</script>
+		<p>&copy; 2024 示例公司</p>
+	</body></html>`
+
+	want := "订单已发货\n\n您好，您的订单 #12345 已发货。\n请注意查收。\n\n- 商品A\n- 商品B\n\n查看物流 (https://example.com/track)\n\n© 2024 示例公司"
+	if got := services.HTMLToText(htmlContent); got != want {
+		t.Errorf("纯文本转换结果不匹配:\n期望: %q\n得到: %q", want, got)
+	}
+
+	msg, err := services.NewMessage().
+		From("s@example.com").
+		To("a@example.com").
+		HTML("<p>你好</p>").
+		Config("c").
+		Build()
+	if err != nil {
+		t.Fatalf("构建邮件失败: %v", err)
+	}
+	if msg.Email.HtmlBody != "<p>你好</p>" || msg.Email.TextBody != "你好" {
+		t.Errorf("只提供HTML时应自动生成纯文本正文，得到 html=%q text=%q", msg.Email.HtmlBody, msg.Email.TextBody)
+	}
+}
+
+// TestAttachmentSources 测试不同来源的附件和大小限制
+func TestAttachmentSources(t *testing.T) {
+	assets := fstest.MapFS{
+		"assets/logo.png": &fstest.MapFile{Data: []byte("\x89PNG\r\n\x1a\n")},
+	}
+	newBuilder := func() *services.MessageBuilder {
+		return services.NewMessage().From("s@example.com").To("a@example.com").Config("c")
+	}
+
+	t.Run("内存、Reader和fs.FS", func(t *testing.T) {
+		msg, err := newBuilder().
+			AttachSource(
+				services.AttachmentFromBytes("report.csv", []byte("a,b\n1,2\n")),
+				services.AttachmentFromReader("notes.txt", strings.NewReader("备注")),
+				services.AttachmentFromFS(assets, "assets/logo.png"),
+			).
+			Build()
+		if err != nil {
+			t.Fatalf("构建邮件失败: %v", err)
+		}
+		if len(msg.Email.Attachments) != 3 {
+			t.Fatalf("期望3个附件，得到%d", len(msg.Email.Attachments))
+		}
+		logo := msg.Email.Attachments[2]
+		if logo.Filename != "logo.png" || logo.Size != 8 {
+			t.Errorf("fs.FS 附件的文件名或大小错误: %s, %d", logo.Filename, logo.Size)
+		}
+	})
+
+	t.Run("超出单个附件限制", func(t *testing.T) {
+		_, err := newBuilder().
+			AttachmentLimits(4, 0).
+			AttachSource(services.AttachmentFromBytes("big.bin", []byte("12345"))).
+			Build()
+		var tooLarge *services.AttachmentTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Name != "big.bin" {
+			t.Errorf("期望 AttachmentTooLargeError，得到 %v", err)
+		}
+	})
+
+	t.Run("超出总大小限制", func(t *testing.T) {
+		_, err := newBuilder().
+			AttachmentLimits(0, 6).
+			AttachSource(
+				services.AttachmentFromBytes("a.bin", []byte("1234")),
+				services.AttachmentFromBytes("b.bin", []byte("5678")),
+			).
+			Build()
+		var tooLarge *services.AttachmentTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Name != "" {
+			t.Errorf("期望总大小超限错误，得到 %v", err)
+		}
+	})
+}
+
+// TestDetectContentType 测试附件MIME类型的推断
+func TestDetectContentType(t *testing.T) {
+	t.Run("扩展名映射", func(t *testing.T) {
+		cases := map[string]string{
+			"photo.jpg":     "image/jpeg",
+			"photo.jpeg":    "image/jpeg",
+			"logo.png":      "image/png",
+			"anim.gif":      "image/gif",
+			"pic.webp":      "image/webp",
+			"icon.svg":      "image/svg+xml",
+			"report.pdf":    "application/pdf",
+			"notes.txt":     "text/plain",
+			"page.html":     "text/html",
+			"page.htm":      "text/html",
+			"data.csv":      "text/csv",
+			"data.json":     "application/json",
+			"data.xml":      "application/xml",
+			"invite.ics":    "text/calendar",
+			"contract.doc":  "application/msword",
+			"contract.docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			"sheet.xls":     "application/vnd.ms-excel",
+			"sheet.xlsx":    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"slides.ppt":    "application/vnd.ms-powerpoint",
+			"slides.pptx":   "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+			"archive.zip":   "application/zip",
+			"archive.gz":    "application/gzip",
+			"song.mp3":      "audio/mpeg",
+			"movie.mp4":     "video/mp4",
+			"message.eml":   "message/rfc822",
+		}
+		for filename, want := range cases {
+			if got := services.DetectContentType(filename, nil); got != want {
+				t.Errorf("%s: 期望 %s，得到 %s", filename, want, got)
+			}
+		}
+	})
+
+	t.Run("扩展名不区分大小写", func(t *testing.T) {
+		if got := services.DetectContentType("SCAN.PDF", nil); got != "application/pdf" {
+			t.Errorf("期望 application/pdf，得到 %s", got)
+		}
+		if got := services.DetectContentType("Report.XLSX", nil); got != "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet" {
+			t.Errorf("期望 OOXML 表格类型，得到 %s", got)
+		}
+	})
+
+	t.Run("内容嗅探", func(t *testing.T) {
+		png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+		if got := services.DetectContentType("upload", png); got != "image/png" {
+			t.Errorf("无扩展名的PNG应被嗅探为 image/png，得到 %s", got)
+		}
+		if got := services.DetectContentType("blob.unknownext", []byte("%PDF-1.7\n")); got != "application/pdf" {
+			t.Errorf("未知扩展名的PDF应被嗅探为 application/pdf，得到 %s", got)
+		}
+		if got := services.DetectContentType("blob", nil); got != "application/octet-stream" {
+			t.Errorf("无法识别时应返回 application/octet-stream，得到 %s", got)
+		}
+	})
+
+	t.Run("自定义注册", func(t *testing.T) {
+		if err := services.RegisterContentType("DWG", "image/vnd.dwg"); err != nil {
+			t.Fatalf("注册MIME类型失败: %v", err)
+		}
+		if got := services.DetectContentType("plan.dwg", nil); got != "image/vnd.dwg" {
+			t.Errorf("期望自定义类型 image/vnd.dwg，得到 %s", got)
+		}
+		if err := services.RegisterContentType(".bad", "not a type"); err == nil {
+			t.Errorf("无效的MIME类型应该返回错误")
+		}
+	})
+}
+
+// TestInlineImages 测试HTML中本地图片改写为内嵌附件
+func TestInlineImages(t *testing.T) {
+	assets := fstest.MapFS{
+		"images/logo.png": &fstest.MapFile{Data: []byte("\x89PNG\r\n\x1a\n")},
+	}
+	htmlContent := `<img src="images/logo.png" alt="Logo"><p>你好</p>` +
+		`<img class="footer" src='./images/logo.png'><img src="https://cdn.example.com/banner.jpg">`
+
+	msg, err := services.NewMessage().
+		From("s@example.com").
+		To("a@example.com").
+		HTMLWithInlineImages(htmlContent, assets).
+		Config("c").
+		Build()
+	if err != nil {
+		t.Fatalf("构建邮件失败: %v", err)
+	}
+
+	if len(msg.Email.Attachments) != 1 {
+		t.Fatalf("同一图片应只内嵌一次，得到%d个附件", len(msg.Email.Attachments))
+	}
+	logo := msg.Email.Attachments[0]
+	if logo.Disposition != email_client_pb.Attachment_INLINE || logo.ContentId == "" {
+		t.Errorf("图片应以内嵌方式添加并带有 Content-ID: %v", logo)
+	}
+	if strings.Count(msg.Email.HtmlBody, "cid:"+logo.ContentId) != 2 {
+		t.Errorf("两处本地图片引用都应改写为 cid:，得到 %s", msg.Email.HtmlBody)
+	}
+	if !strings.Contains(msg.Email.HtmlBody, `src="https://cdn.example.com/banner.jpg"`) {
+		t.Errorf("远程图片地址不应被改写，得到 %s", msg.Email.HtmlBody)
+	}
+
+	_, err = services.NewMessage().HTMLWithInlineImages(`<img src="missing.png">`, assets).Build()
+	if err == nil {
+		t.Errorf("引用不存在的本地图片时应返回错误")
+	}
+}
+
+// TestTemplateVariables 测试模板变量到 google.protobuf.Struct 的转换
+func TestTemplateVariables(t *testing.T) {
+	type order struct {
+		ID    string  `json:"id"`
+		Total float64 `json:"total"`
+	}
+	shippedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	variables, err := services.TemplateVariables(map[string]any{
+		"name":       "张三",
+		"vip":        true,
+		"items":      []string{"商品A", "商品B"},
+		"order":      order{ID: "12345", Total: 99.5},
+		"shipped_at": shippedAt,
+	})
+	if err != nil {
+		t.Fatalf("转换模板变量失败: %v", err)
+	}
+
+	fields := variables.GetFields()
+	if fields["name"].GetStringValue() != "张三" || !fields["vip"].GetBoolValue() {
+		t.Errorf("字符串或布尔变量转换错误: %v", fields)
+	}
+	if len(fields["items"].GetListValue().GetValues()) != 2 {
+		t.Errorf("列表变量转换错误: %v", fields["items"])
+	}
+	if fields["order"].GetStructValue().GetFields()["id"].GetStringValue() != "12345" {
+		t.Errorf("结构体变量应按 json 标签转换: %v", fields["order"])
+	}
+	if fields["shipped_at"].GetStringValue() != "2024-05-01T09:00:00Z" {
+		t.Errorf("时间变量应转换为 RFC 3339 字符串，得到 %v", fields["shipped_at"])
+	}
+
+	if _, err := services.TemplateVariables(map[string]any{"bad": make(chan int)}); err == nil {
+		t.Errorf("无法编码的变量应返回错误")
+	}
+}
+
+// TestClientTemplates 测试客户端模板渲染
+func TestClientTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":      {Data: []byte(`<html><body>{{template "content" .}}{{template "footer" .}}</body></html>`)},
+		"partials/footer.html":   {Data: []byte(`<p>{{.Company}}</p>`)},
+		"welcome/subject.txt":    {Data: []byte("Welcome, {{.Name}}\n")},
+		"welcome/subject.zh.txt": {Data: []byte("欢迎，{{.Name}}")},
+		"welcome/body.html":      {Data: []byte(`<h1>Hello {{.Name}}</h1>`)},
+		"welcome/body.zh.html":   {Data: []byte(`<h1>你好 {{.Name}}</h1>`)},
+		"welcome/body.txt":       {Data: []byte(`Hello {{.Name}}`)},
+		"reset/subject.txt":      {Data: []byte("Reset password")},
+		"reset/body.html":        {Data: []byte(`<a href="{{.Link}}">reset</a>`)},
+		"broken/subject.txt":     {Data: []byte("{{.Name}}\n{{.Name}}")},
+		"broken/body.txt":        {Data: []byte("body")},
+		"nobody/subject.txt":     {Data: []byte("subject")},
+	}
+	set := emailtemplate.New(fsys, emailtemplate.WithLayout("base"))
+	data := map[string]string{"Name": "<Tom>", "Company": "ACME", "Link": "https://example.com/r"}
+
+	t.Run("默认语言与布局", func(t *testing.T) {
+		rendered, err := set.Render("welcome", "", data)
+		if err != nil {
+			t.Fatalf("渲染失败: %v", err)
+		}
+		if rendered.Subject != "Welcome, <Tom>" {
+			t.Errorf("标题错误: %q", rendered.Subject)
+		}
+		if rendered.HTML != "<html><body><h1>Hello &lt;Tom&gt;</h1><p>ACME</p></body></html>" {
+			t.Errorf("HTML正文应使用布局并转义: %q", rendered.HTML)
+		}
+		if rendered.Text != "Hello <Tom>" {
+			t.Errorf("纯文本正文不应转义: %q", rendered.Text)
+		}
+	})
+
+	t.Run("语言回退", func(t *testing.T) {
+		email, err := set.Email("welcome", "zh_CN", data)
+		if err != nil {
+			t.Fatalf("渲染失败: %v", err)
+		}
+		if email.GetTitle() != "欢迎，<Tom>" || !strings.Contains(email.GetHtmlBody(), "你好 &lt;Tom&gt;") {
+			t.Errorf("zh-CN 应回退到 zh 模板: %q %q", email.GetTitle(), email.GetHtmlBody())
+		}
+		if email.GetTextBody() != "Hello <Tom>" {
+			t.Errorf("没有 zh 纯文本模板时应回退到默认模板: %q", email.GetTextBody())
+		}
+		if string(email.GetContent()) != email.GetHtmlBody() || email.GetEmailType() != services.EmailTypeNormal {
+			t.Errorf("邮件内容应优先使用HTML正文")
+		}
+	})
+
+	t.Run("自动生成纯文本正文", func(t *testing.T) {
+		email, err := set.Email("reset", "en", data)
+		if err != nil {
+			t.Fatalf("渲染失败: %v", err)
+		}
+		if email.GetTextBody() != "reset (https://example.com/r)\n\nACME" {
+			t.Errorf("纯文本正文应由HTML生成: %q", email.GetTextBody())
+		}
+	})
+
+	t.Run("错误", func(t *testing.T) {
+		if _, err := set.Render("missing", "", data); !errors.Is(err, emailtemplate.ErrTemplateNotFound) {
+			t.Errorf("期望 ErrTemplateNotFound，得到 %v", err)
+		}
+		if _, err := set.Render("nobody", "", data); !errors.Is(err, emailtemplate.ErrTemplateNotFound) {
+			t.Errorf("没有正文时期望 ErrTemplateNotFound，得到 %v", err)
+		}
+		if _, err := set.Render("broken", "", data); !errors.Is(err, emailtemplate.ErrInvalidSubject) {
+			t.Errorf("标题包含换行时期望 ErrInvalidSubject，得到 %v", err)
+		}
+		strict := emailtemplate.New(fsys, emailtemplate.WithMissingKeyError())
+		if _, err := strict.Render("welcome", "", map[string]string{}); err == nil {
+			t.Errorf("缺少变量时应返回错误")
+		}
+	})
+}
+
+// fakeEmailServer 是用于测试的邮件服务端，未设置的方法返回 Unimplemented
+type fakeEmailServer struct {
+	email_client_pb.UnimplementedEmailServiceServer
+	sendEmail  func(*email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error)
+	sendEmails func(*email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error)
+	cancelled  []string
+
+	getSentEmails func(*email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error)
+	deliveries    map[string]*email_client_pb.GetDeliveryStatusResponse
+
+	streamed []*email_client_pb.Email // 通过 SendEmailsStream 收到的邮件
+
+	uploadMetadata   *email_client_pb.AttachmentMetadata
+	uploadChunks     [][]byte
+	uploadSizeOffset int64 // 服务端报告的接收字节数与实际值的差，用于模拟大小不一致
+}
+
+func (s *fakeEmailServer) SendEmail(_ context.Context, req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+	return s.sendEmail(req)
+}
+
+func (s *fakeEmailServer) CancelScheduledEmail(_ context.Context, req *email_client_pb.CancelScheduledEmailRequest) (*email_client_pb.CancelScheduledEmailResponse, error) {
+	s.cancelled = append(s.cancelled, req.GetId())
+	return &email_client_pb.CancelScheduledEmailResponse{Success: true}, nil
+}
+
+func (s *fakeEmailServer) SendEmailsStream(stream grpc.BidiStreamingServer[email_client_pb.SendEmailsStreamRequest, email_client_pb.SendEmailEvent]) error {
+	for i := int32(0); ; i++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.streamed = append(s.streamed, req.GetEmail())
+		if req.GetEmail().GetTitle() == "abort" {
+			return status.Error(codes.Internal, "服务端异常")
+		}
+		result := &email_client_pb.SendResult{Index: i, EmailId: "id-" + req.GetEmail().GetTitle()}
+		if req.GetConfigId() == "" || req.GetEmail().GetTitle() == "fail" {
+			result = &email_client_pb.SendResult{Index: i, Code: int32(codes.InvalidArgument), ErrorMessage: "发送失败"}
+		}
+		if err := stream.Send(&email_client_pb.SendEmailEvent{Result: result, Timestamp: timestamppb.Now()}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *fakeEmailServer) UploadAttachment(stream grpc.ClientStreamingServer[email_client_pb.UploadAttachmentRequest, email_client_pb.UploadAttachmentResponse]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.GetMetadata() == nil {
+		return status.Error(codes.InvalidArgument, "第一条消息必须是元数据")
+	}
+	s.uploadMetadata, s.uploadChunks = first.GetMetadata(), nil
+	var size int64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetMetadata() != nil {
+			return status.Error(codes.InvalidArgument, "元数据只能发送一次")
+		}
+		s.uploadChunks = append(s.uploadChunks, req.GetChunk())
+		size += int64(len(req.GetChunk()))
+	}
+	return stream.SendAndClose(&email_client_pb.UploadAttachmentResponse{
+		Success:      true,
+		AttachmentId: "att-" + s.uploadMetadata.GetFilename(),
+		Size:         size + s.uploadSizeOffset,
+	})
+}
+
+func (s *fakeEmailServer) GetSentEmails(_ context.Context, req *email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+	return s.getSentEmails(req)
+}
+
+func (s *fakeEmailServer) GetEmail(_ context.Context, req *email_client_pb.GetEmailRequest) (*email_client_pb.GetEmailResponse, error) {
+	delivery, ok := s.deliveries[req.GetEmailId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "邮件不存在")
+	}
+	return &email_client_pb.GetEmailResponse{
+		Email:    &email_client_pb.Email{Id: req.GetEmailId(), Title: "订单确认"},
+		ConfigId: "config",
+		State:    delivery.GetState(),
+	}, nil
+}
+
+func (s *fakeEmailServer) GetDeliveryStatus(_ context.Context, req *email_client_pb.GetDeliveryStatusRequest) (*email_client_pb.GetDeliveryStatusResponse, error) {
+	delivery, ok := s.deliveries[req.GetEmailId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "邮件不存在")
+	}
+	return delivery, nil
+}
+
+func (s *fakeEmailServer) SendEmails(_ context.Context, req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+	return s.sendEmails(req)
+}
+
+// newTestEmailService 启动内存中的 gRPC 服务端，并返回连接到它的邮件服务客户端
+func newTestEmailService(t *testing.T, srv email_client_pb.EmailServiceServer, opts ...grpc.DialOption) *services.EmailServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	email_client_pb.RegisterEmailServiceServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("无法连接测试服务端: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return services.NewEmailServiceClient(conn, 5*time.Second, 20, false)
+}
+
+// TestBatchResult 测试批量发送的逐封结果与失败重试
+func TestBatchResult(t *testing.T) {
+	var attempts [][]string
+	srv := &fakeEmailServer{
+		sendEmails: func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+			var titles []string
+			resp := &email_client_pb.SendEmailsResponse{}
+			for i, email := range req.GetEmails() {
+				titles = append(titles, email.GetTitle())
+				result := &email_client_pb.SendResult{Index: int32(i), EmailId: "id-" + email.GetTitle()}
+				// 第一次发送时标题以 fail 开头的邮件失败
+				if len(attempts) == 0 && strings.HasPrefix(email.GetTitle(), "fail") {
+					result = &email_client_pb.SendResult{Index: int32(i), Code: int32(codes.Unavailable), ErrorMessage: "SMTP 暂时不可用"}
+				}
+				resp.Results = append(resp.Results, result)
+			}
+			attempts = append(attempts, titles)
+			return resp, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+
+	req := &email_client_pb.SendEmailsRequest{ConfigId: "config", Emails: []*email_client_pb.Email{
+		{Title: "ok1"}, {Title: "fail1"}, {Title: "ok2"}, {Title: "fail2"},
+	}}
+	result, err := emailService.SendEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("批量发送失败: %v", err)
+	}
+	if len(result.Succeeded()) != 2 || len(result.Failed()) != 2 {
+		t.Fatalf("期望 2 封成功 2 封失败，得到 %d/%d", len(result.Succeeded()), len(result.Failed()))
+	}
+	if result.Failed()[0].GetIndex() != 1 || result.FailedEmails()[1].GetTitle() != "fail2" {
+		t.Errorf("失败结果与请求中的邮件不对应: %v", result.Failed())
+	}
+
+	retry, err := result.RetryFailed(context.Background())
+	if err != nil {
+		t.Fatalf("重试失败: %v", err)
+	}
+	if len(attempts) != 2 || strings.Join(attempts[1], ",") != "fail1,fail2" {
+		t.Errorf("重试应只发送失败的邮件，实际发送: %v", attempts)
+	}
+	if len(retry.Failed()) != 0 || retry.GetResults()[0].GetEmailId() != "id-fail1" {
+		t.Errorf("重试结果错误: %v", retry.GetResults())
+	}
+	if again, err := retry.RetryFailed(context.Background()); again != nil || err != nil {
+		t.Errorf("没有失败的邮件时不应重试")
+	}
+
+	t.Run("兼容旧版服务端", func(t *testing.T) {
+		srv.sendEmails = func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+			return &email_client_pb.SendEmailsResponse{Success: false, Message: "配置不存在"}, nil
+		}
+		result, err := emailService.SendEmails(context.Background(), req)
+		if err != nil {
+			t.Fatalf("批量发送失败: %v", err)
+		}
+		if len(result.GetResults()) != len(req.GetEmails()) || len(result.Failed()) != len(req.GetEmails()) {
+			t.Fatalf("旧版服务端失败时所有邮件都应视为失败: %v", result.GetResults())
+		}
+		if result.Failed()[0].GetErrorMessage() != "配置不存在" {
+			t.Errorf("失败信息应来自响应消息: %v", result.Failed()[0])
+		}
+	})
+}
+
+// TestBulkSender 测试批量发送的分块、并发与结果汇总
+func TestBulkSender(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		chunkSizes []int
+	)
+	srv := &fakeEmailServer{
+		sendEmails: func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+			mu.Lock()
+			chunkSizes = append(chunkSizes, len(req.GetEmails()))
+			mu.Unlock()
+			if req.GetEmails()[0].GetTitle() == "reject" {
+				return nil, status.Error(codes.ResourceExhausted, "请求过大")
+			}
+			resp := &email_client_pb.SendEmailsResponse{}
+			for i, email := range req.GetEmails() {
+				result := &email_client_pb.SendResult{Index: int32(i), EmailId: "id-" + email.GetTitle()}
+				if email.GetTitle() == "fail" {
+					result = &email_client_pb.SendResult{Index: int32(i), Code: int32(codes.InvalidArgument), ErrorMessage: "收件人无效"}
+				}
+				resp.Results = append(resp.Results, result)
+			}
+			return resp, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+
+	emails := make([]*email_client_pb.Email, 10)
+	for i := range emails {
+		emails[i] = &email_client_pb.Email{Title: fmt.Sprintf("mail%d", i)}
+	}
+	emails[4].Title = "fail"
+	emails[6].Title = "reject" // 第三个分块的第一封，整个分块请求失败
+
+	var progress []services.BulkProgress
+	sender := services.NewBulkSender(emailService,
+		services.WithChunkSize(3),
+		services.WithConcurrency(2),
+		services.WithProgress(func(p services.BulkProgress) { progress = append(progress, p) }),
+	)
+	result, err := sender.Send(context.Background(), "config", emails)
+	if err != nil {
+		t.Fatalf("批量发送失败: %v", err)
+	}
+
+	if len(chunkSizes) != 4 {
+		t.Errorf("10 封邮件每块 3 封应拆分为 4 个分块，实际 %v", chunkSizes)
+	}
+	if len(result.GetResults()) != 10 || result.GetResults()[9].GetEmailId() != "id-mail9" {
+		t.Fatalf("结果应与原邮件列表一一对应: %v", result.GetResults())
+	}
+	var failed []int32
+	for _, r := range result.Failed() {
+		failed = append(failed, r.GetIndex())
+	}
+	if fmt.Sprint(failed) != "[4 6 7 8]" || result.GetSuccess() {
+		t.Errorf("失败的邮件下标错误: %v", failed)
+	}
+	if codes.Code(result.GetResults()[7].GetCode()) != codes.ResourceExhausted {
+		t.Errorf("分块请求失败时应使用请求错误的状态码: %v", result.GetResults()[7])
+	}
+	if len(progress) != 4 || progress[3].ChunksDone != 4 || progress[3].Sent != 6 || progress[3].Failed != 4 {
+		t.Errorf("进度回调错误: %+v", progress)
+	}
+
+	t.Run("按字节数分块", func(t *testing.T) {
+		chunkSizes = nil
+		large := make([]*email_client_pb.Email, 4)
+		for i := range large {
+			large[i] = &email_client_pb.Email{Title: "big", Content: make([]byte, 600)}
+		}
+		sender := services.NewBulkSender(emailService, services.WithChunkBytes(1000))
+		if _, err := sender.Send(context.Background(), "config", large); err != nil {
+			t.Fatalf("批量发送失败: %v", err)
+		}
+		if len(chunkSizes) != 4 {
+			t.Errorf("超过字节限制时每封邮件应单独成块，实际 %v", chunkSizes)
+		}
+	})
+
+	t.Run("速率限制", func(t *testing.T) {
+		limiter := client.NewRateLimiter(client.RateLimiterConfig{RequestsPerSecond: 1000, MaxBurst: 1}, false)
+		limiter.Allow() // 耗尽令牌
+		sender := services.NewBulkSender(emailService, services.WithBulkRateLimiter(limiter))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := sender.Send(ctx, "config", emails[:2]); err != nil {
+			t.Fatalf("令牌恢复后应继续发送: %v", err)
+		}
+
+		limiter.SetRate(0.001)
+		limiter.Allow()
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		result, err := sender.Send(ctx, "config", emails[:2])
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("等待令牌超时时应返回上下文错误，得到 %v", err)
+		}
+		if len(result.Failed()) != 2 {
+			t.Errorf("未发送的邮件应标记为失败: %v", result.GetResults())
+		}
+	})
+}
+
+// TestSendStream 测试流式发送邮件
+func TestSendStream(t *testing.T) {
+	emailService := newTestEmailService(t, &fakeEmailServer{})
+	ctx := context.Background()
+
+	if _, err := emailService.SendStream(ctx, "", nil); !errors.Is(err, services.ErrEmptyConfigID) {
+		t.Errorf("配置ID为空时期望 ErrEmptyConfigID，得到 %v", err)
+	}
+
+	send := func(titles ...string) []services.StreamResult {
+		emails := make(chan *email_client_pb.Email, len(titles))
+		for _, title := range titles {
+			emails <- &email_client_pb.Email{Title: title}
+		}
+		close(emails)
+		results, err := emailService.SendStream(ctx, "config", emails)
+		if err != nil {
+			t.Fatalf("创建发送流失败: %v", err)
+		}
+		var collected []services.StreamResult
+		for result := range results {
+			collected = append(collected, result)
+		}
+		return collected
+	}
+
+	results := send("a", "fail", "b")
+	if len(results) != 3 {
+		t.Fatalf("期望 3 个发送事件，得到 %d", len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil || result.Event.GetResult().GetIndex() != int32(i) {
+			t.Errorf("发送事件 %d 错误: %+v", i, result)
+		}
+	}
+	if results[0].Event.GetResult().GetEmailId() != "id-a" || results[1].Event.GetResult().GetCode() != int32(codes.InvalidArgument) {
+		t.Errorf("发送结果错误: %v %v", results[0].Event, results[1].Event)
+	}
+
+	results = send("a", "abort", "b")
+	if len(results) != 2 || results[1].Err == nil || status.Code(errors.Unwrap(results[1].Err)) != codes.Internal {
+		t.Errorf("流异常结束时最后一个结果应包含错误: %+v", results)
+	}
+
+	// 收件人地址在副本上规范化，地址不合法的邮件不会发往服务端，事件序号仍对应邮件在流中的位置
+	srv := &fakeEmailServer{}
+	emailService = newTestEmailService(t, srv)
+	input := []*email_client_pb.Email{
+		{Title: "a", To: []string{"张三 <Zhang@Example.COM>"}},
+		{Title: "bad", To: []string{"bob@@example.com"}},
+		{Title: "b", To: []string{"b@example.com"}},
+	}
+	emails := make(chan *email_client_pb.Email, len(input))
+	for _, email := range input {
+		emails <- email
+	}
+	close(emails)
+	stream, err := emailService.SendStream(ctx, "config", emails)
+	if err != nil {
+		t.Fatalf("创建发送流失败: %v", err)
+	}
+	byIndex := make(map[int32]*email_client_pb.SendResult)
+	for result := range stream {
+		if result.Err != nil {
+			t.Fatalf("发送流异常结束: %v", result.Err)
+		}
+		byIndex[result.Event.GetResult().GetIndex()] = result.Event.GetResult()
+	}
+	if len(byIndex) != 3 || byIndex[0].GetEmailId() != "id-a" || byIndex[2].GetEmailId() != "id-b" ||
+		codes.Code(byIndex[1].GetCode()) != codes.InvalidArgument || !strings.Contains(byIndex[1].GetErrorMessage(), "bob@@example.com") {
+		t.Errorf("发送事件错误: %v", byIndex)
+	}
+	if len(srv.streamed) != 2 || srv.streamed[0].GetTo()[0] != "Zhang@example.com" {
+		t.Errorf("服务端应只收到规范化后的合法邮件: %v", srv.streamed)
+	}
+	if input[0].GetTo()[0] != "张三 <Zhang@Example.COM>" {
+		t.Errorf("调用方的邮件不应被修改: %v", input[0].GetTo())
+	}
+}
+
+// TestScheduleEmail 测试计划发送与取消
+func TestScheduleEmail(t *testing.T) {
+	var scheduledAt time.Time
+	srv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			scheduledAt = req.GetScheduledAt().AsTime()
+			return &email_client_pb.SendEmailResponse{Success: true, EmailId: "sched-1", Scheduled: req.GetScheduledAt() != nil}, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+	ctx := context.Background()
+
+	msg, err := services.NewMessage().From("a@example.com").To("b@example.com").Subject("日报").Text("内容").Config("config").Build()
+	if err != nil {
+		t.Fatalf("构建邮件失败: %v", err)
+	}
+
+	loc := time.FixedZone("UTC+8", 8*3600)
+	at := time.Now().In(loc).Add(24 * time.Hour).Truncate(time.Second)
+	resp, err := emailService.Schedule(ctx, msg, at)
+	if err != nil {
+		t.Fatalf("计划发送失败: %v", err)
+	}
+	if resp.GetEmailId() != "sched-1" || !scheduledAt.Equal(at) {
+		t.Errorf("计划发送时间错误: 期望 %v，得到 %v", at, scheduledAt)
+	}
+
+	if _, err := emailService.Schedule(ctx, msg, time.Now().Add(-time.Minute)); !errors.Is(err, services.ErrScheduledTimeInPast) {
+		t.Errorf("期望 ErrScheduledTimeInPast，得到 %v", err)
+	}
+
+	if _, err := emailService.Cancel(ctx, ""); !errors.Is(err, services.ErrEmptyScheduleID) {
+		t.Errorf("期望 ErrEmptyScheduleID，得到 %v", err)
+	}
+	if _, err := emailService.Cancel(ctx, "sched-1"); err != nil || len(srv.cancelled) != 1 || srv.cancelled[0] != "sched-1" {
+		t.Errorf("取消计划邮件失败: %v %v", err, srv.cancelled)
+	}
+
+	// 旧版服务端忽略 scheduled_at 并立即发送
+	srv.sendEmail = func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+		return &email_client_pb.SendEmailResponse{Success: true, EmailId: "sent-1"}, nil
+	}
+	if resp, err := emailService.Schedule(ctx, msg, at); !errors.Is(err, services.ErrSchedulingNotSupported) || resp == nil {
+		t.Errorf("期望 ErrSchedulingNotSupported 和发送响应，得到 %v %v", resp, err)
+	}
+}
+
+// TestIdempotencyKey 测试幂等键的自动生成与在重试中保持不变
+func TestIdempotencyKey(t *testing.T) {
+	var keys []string
+	srv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			keys = append(keys, req.GetIdempotencyKey())
+			// 每次逻辑发送的第一次尝试都返回可重试的错误
+			if len(keys)%2 == 1 {
+				return nil, status.Error(codes.Unavailable, "暂时不可用")
+			}
+			return &email_client_pb.SendEmailResponse{Success: true}, nil
+		},
+	}
+	retry := middleware.WithRetryAndMetrics(false, nil, middleware.RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond})
+	emailService := newTestEmailService(t, srv, grpc.WithUnaryInterceptor(retry))
+	ctx := context.Background()
+
+	if _, err := emailService.SendNormalEmail(ctx, "标题", []byte("内容"), "a@example.com", []string{"b@example.com"}, "config"); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(keys) != 2 || len(keys[0]) != 32 || keys[0] != keys[1] {
+		t.Fatalf("重试时应复用自动生成的幂等键: %v", keys)
+	}
+
+	if _, err := emailService.SendNormalEmail(ctx, "标题", []byte("内容"), "a@example.com", []string{"b@example.com"}, "config"); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(keys) != 4 || keys[2] == keys[0] {
+		t.Errorf("不同的逻辑发送应使用不同的幂等键: %v", keys)
+	}
+
+	_, err := emailService.SendNormalEmail(ctx, "标题", []byte("内容"), "a@example.com", []string{"b@example.com"}, "config",
+		services.WithIdempotencyKey("order-12345-shipped"))
+	if err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if keys[4] != "order-12345-shipped" || keys[5] != "order-12345-shipped" {
+		t.Errorf("应使用调用方提供的幂等键: %v", keys[4:])
+	}
+
+	// 重复使用同一个请求对象时，自动生成的幂等键不会写回请求，两次发送使用不同的幂等键
+	req := &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{To: []string{"b@example.com"}}, ConfigId: "config"}
+	for range 2 {
+		if _, err := emailService.SendEmail(ctx, req); err != nil {
+			t.Fatalf("发送失败: %v", err)
+		}
+	}
+	if req.GetIdempotencyKey() != "" || keys[6] == "" || keys[6] == keys[8] {
+		t.Errorf("重复发送同一个请求对象应使用不同的幂等键: %q, %v", req.GetIdempotencyKey(), keys[6:])
+	}
+
+	box, err := outbox.Open(t.TempDir(), emailService.SendEmail)
+	if err != nil {
+		t.Fatalf("打开发件箱失败: %v", err)
+	}
+	defer box.Close()
+	for range 2 {
+		if _, _, err := box.Send(ctx, req); err != nil {
+			t.Fatalf("发送失败: %v", err)
+		}
+	}
+	if req.GetIdempotencyKey() != "" || keys[10] == keys[12] {
+		t.Errorf("通过发件箱重复发送同一个请求对象应使用不同的幂等键: %q, %v", req.GetIdempotencyKey(), keys[10:])
+	}
+
+	var batchKeys []string
+	srv.sendEmails = func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+		batchKeys = append(batchKeys, req.GetIdempotencyKey())
+		return &email_client_pb.SendEmailsResponse{Success: true}, nil
+	}
+	batchReq := &email_client_pb.SendEmailsRequest{Emails: []*email_client_pb.Email{{To: []string{"b@example.com"}}}}
+	for range 2 {
+		if _, err := emailService.SendEmails(ctx, batchReq); err != nil {
+			t.Fatalf("批量发送失败: %v", err)
+		}
+	}
+	if batchReq.GetIdempotencyKey() != "" || len(batchKeys) != 2 || batchKeys[0] == batchKeys[1] {
+		t.Errorf("重复批量发送同一个请求对象应使用不同的幂等键: %q, %v", batchReq.GetIdempotencyKey(), batchKeys)
+	}
+}
+
+// TestOutbox 测试发件箱的持久化、按序发送与死信处理
+func TestOutbox(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	var (
+		online bool
+		sent   []string
+	)
+	send := func(_ context.Context, req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+		if !online {
+			return nil, status.Error(codes.Unavailable, "连接失败")
+		}
+		if req.GetEmail().GetTitle() == "invalid" {
+			return &email_client_pb.SendEmailResponse{Success: false, Message: "收件人被拒绝"}, nil
+		}
+		sent = append(sent, req.GetEmail().GetTitle())
+		return &email_client_pb.SendEmailResponse{Success: true}, nil
+	}
+	request := func(title string) *email_client_pb.SendEmailRequest {
+		return &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{Title: title}, ConfigId: "config"}
+	}
+
+	box, err := outbox.Open(dir, send)
+	if err != nil {
+		t.Fatalf("打开发件箱失败: %v", err)
+	}
+	for _, title := range []string{"first", "invalid", "second"} {
+		_, queued, err := box.Send(ctx, request(title))
+		if err != nil || !queued {
+			t.Fatalf("服务端不可达时邮件应进入发件箱: queued=%v err=%v", queued, err)
+		}
+	}
+	if box.Depth() != 3 {
+		t.Fatalf("期望发件箱中有 3 封邮件，得到 %d", box.Depth())
+	}
+	key := box.Pending()[0].Request.GetIdempotencyKey()
+	if key == "" {
+		t.Errorf("入队的邮件应带有幂等键")
+	}
+
+	// 连接未恢复时排空失败，邮件保留在队列中
+	if _, err := box.Drain(ctx); status.Code(err) != codes.Unavailable || box.Pending()[0].Attempts != 1 {
+		t.Errorf("连接失败时应停止发送并记录尝试次数: %v", err)
+	}
+	box.Close()
+
+	// 模拟进程重启，并在日志末尾留下一条写了一半的记录
+	f, err := os.OpenFile(filepath.Join(dir, "outbox.log"), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("打开发件箱日志失败: %v", err)
+	}
+	f.WriteString(`{"op":"enq`)
+	f.Close()
+
+	online = true
+	box, err = outbox.Open(dir, send)
+	if err != nil {
+		t.Fatalf("重新打开发件箱失败: %v", err)
+	}
+	defer box.Close()
+	pending := box.Pending()
+	if len(pending) != 3 || pending[0].Attempts != 1 || pending[0].Request.GetIdempotencyKey() != key {
+		t.Fatalf("重启后应恢复发件箱中的邮件: %+v", pending)
+	}
+
+	n, err := box.Drain(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("期望发送 2 封邮件，得到 %d: %v", n, err)
+	}
+	if strings.Join(sent, ",") != "first,second" {
+		t.Errorf("发件箱应按入队顺序发送: %v", sent)
+	}
+	dead := box.DeadLetters()
+	if box.Depth() != 0 || len(dead) != 1 || dead[0].LastError != "收件人被拒绝" {
+		t.Fatalf("被服务端拒绝的邮件应进入死信队列: %+v", dead)
+	}
+
+	// 队列为空时直接发送
+	if _, queued, err := box.Send(ctx, request("direct")); err != nil || queued {
+		t.Errorf("连接正常时应直接发送: queued=%v err=%v", queued, err)
+	}
+
+	if err := box.Requeue(dead[0].ID); err != nil || box.Depth() != 1 || len(box.DeadLetters()) != 0 {
+		t.Errorf("死信重新入队失败: %v", err)
+	}
+	box.Drain(ctx)
+	if err := box.Discard(box.DeadLetters()[0].ID); err != nil || len(box.DeadLetters()) != 0 {
+		t.Errorf("删除死信失败: %v", err)
+	}
+	if err := box.Discard("missing"); !errors.Is(err, outbox.ErrEntryNotFound) {
+		t.Errorf("期望 ErrEntryNotFound，得到 %v", err)
+	}
+}
+
+// TestOutboxAutoDrain 测试连接始终保持 Ready 时，暂时性错误后入队的邮件由后台自动发送
+func TestOutboxAutoDrain(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu    sync.Mutex
+		calls int
+		sent  []string
+	)
+	srv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if calls == 1 {
+				return nil, status.Error(codes.ResourceExhausted, "服务端繁忙")
+			}
+			sent = append(sent, req.GetEmail().GetTitle())
+			return &email_client_pb.SendEmailResponse{Success: true}, nil
+		},
+	}
+	emailClient := newTestEmailClient(t, srv, client.WithOutbox(t.TempDir(), outbox.WithAutoDrain(10*time.Millisecond)))
+	box := emailClient.Outbox()
+
+	titles := []string{"1", "2", "3", "4", "5"}
+	for _, title := range titles {
+		req := &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{Title: title}, ConfigId: "config"}
+		if _, queued, err := box.Send(ctx, req); err != nil || !queued {
+			t.Fatalf("邮件 %s 应进入发件箱: queued=%v err=%v", title, queued, err)
+		}
+	}
+
+	// 没有健康检查触发的重连，队列仍会被排空
+	deadline := time.Now().Add(5 * time.Second)
+	for box.Depth() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	got := strings.Join(sent, ",")
+	mu.Unlock()
+	if box.Depth() != 0 || got != strings.Join(titles, ",") {
+		t.Fatalf("发件箱应自动按顺序发送全部邮件: 剩余 %d 封, 已发送 %v", box.Depth(), got)
+	}
+	if state := emailClient.GetConnManager().GetState(); state != connectivity.Ready {
+		t.Errorf("连接应保持 Ready，得到 %v", state)
+	}
+
+	// 客户端速率限制的拒绝不入队，直接返回给调用方
+	limited := newTestEmailClient(t, srv,
+		client.WithOutbox(t.TempDir()),
+		client.WithRateLimiterConfig(client.RateLimiterConfig{RequestsPerSecond: 0.001, MaxBurst: 1}),
+	)
+	req := &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{Title: "limited"}, ConfigId: "config"}
+	_, _, err := limited.Outbox().Send(ctx, req)
+	_, queued, limitErr := limited.Outbox().Send(ctx, req)
+	var rateErr *client.RateLimitExceededError
+	if err != nil || queued || !errors.As(limitErr, &rateErr) || limited.Outbox().Depth() != 0 {
+		t.Errorf("速率限制的拒绝不应入队: err=%v queued=%v limitErr=%v depth=%d", err, queued, limitErr, limited.Outbox().Depth())
+	}
+}
+
+// TestAllSentEmails 测试已发送邮件的自动分页迭代器
+func TestAllSentEmails(t *testing.T) {
+	const total = 7
+	var requests []*email_client_pb.GetSentEmailsRequest
+	srv := &fakeEmailServer{
+		getSentEmails: func(req *email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+			requests = append(requests, req)
+			start := 0
+			if req.GetCursor() != "" {
+				fmt.Sscanf(req.GetCursor(), "c%d", &start)
+			}
+			end := min(start+int(req.GetLimit()), total)
+			resp := &email_client_pb.GetSentEmailsResponse{HasMore: end < total}
+			for i := start; i < end; i++ {
+				resp.Emails = append(resp.Emails, &email_client_pb.Email{Id: fmt.Sprintf("e%d", i)})
+			}
+			if resp.HasMore {
+				resp.NextCursor = fmt.Sprintf("c%d", end)
+			}
+			return resp, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+	emailService.SetDefaultPageSize(3)
+	ctx := context.Background()
+
+	collect := func(seq func(func(*email_client_pb.Email, error) bool)) ([]string, error) {
+		var ids []string
+		for email, err := range seq {
+			if err != nil {
+				return ids, err
+			}
+			ids = append(ids, email.GetId())
+		}
+		return ids, nil
+	}
+
+	ids, err := collect(emailService.AllSentEmails(ctx, &email_client_pb.GetSentEmailsRequest{EmailType: services.EmailTypeNormal}))
+	if err != nil || len(ids) != total || ids[6] != "e6" {
+		t.Fatalf("应按页获取全部邮件: %v %v", ids, err)
+	}
+	if len(requests) != 3 || requests[0].GetLimit() != 3 || requests[2].GetEmailType() != services.EmailTypeNormal {
+		t.Errorf("分页请求错误: %v", requests)
+	}
+
+	requests = nil
+	ids, _ = collect(emailService.AllSentEmails(ctx, nil, services.WithMaxItems(4)))
+	if len(ids) != 4 || len(requests) != 2 || requests[1].GetLimit() != 1 {
+		t.Errorf("达到数量上限时应停止并只请求需要的记录: %v %v", ids, requests)
+	}
+
+	// 提前结束迭代时不再请求下一页
+	requests = nil
+	for range emailService.AllSentEmails(ctx, nil) {
+		break
+	}
+	if len(requests) != 1 {
+		t.Errorf("提前结束迭代时不应继续请求: %d", len(requests))
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := collect(emailService.AllSentEmails(cancelled, nil)); !errors.Is(err, context.Canceled) {
+		t.Errorf("上下文取消时应返回 context.Canceled，得到 %v", err)
+	}
+}
+
+// TestSentEmailQuery 测试已发送邮件查询条件的组合与校验
+func TestSentEmailQuery(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	req, err := services.NewSentEmailQuery().
+		Type(services.EmailTypeNormal).
+		Recipient("张三 <zhangsan@example.com>").
+		Sender("noreply@example.com").
+		SubjectContains(" 订单 ").
+		Config("config").
+		SentBetween(from, to).
+		HasAttachments(false).
+		Limit(50).
+		Build()
+	if err != nil {
+		t.Fatalf("构建查询失败: %v", err)
+	}
+	if req.GetRecipient() != "zhangsan@example.com" || req.GetSender() != "noreply@example.com" || req.GetSubjectContains() != "订单" {
+		t.Errorf("地址和标题过滤条件错误: %v", req)
+	}
+	if !req.GetSentAfter().AsTime().Equal(from) || !req.GetSentBefore().AsTime().Equal(to) || req.GetConfigId() != "config" {
+		t.Errorf("时间范围或配置过滤条件错误: %v", req)
+	}
+	if req.HasAttachments == nil || req.GetHasAttachments() || req.GetLimit() != 50 {
+		t.Errorf("附件或分页条件错误: %v", req)
+	}
+
+	// 地址过滤条件与发送时的收件人使用相同的规范化形式
+	req, err = services.NewSentEmailQuery().Recipient("a@例子.中国").Sender("noreply@Example.COM").Build()
+	if err != nil || req.GetRecipient() != "a@xn--fsqu00a.xn--fiqs8s" || req.GetSender() != "noreply@example.com" {
+		t.Errorf("地址过滤条件应被规范化: %v %v", req, err)
+	}
+
+	if req, _ := services.NewSentEmailQuery().Build(); req.HasAttachments != nil {
+		t.Errorf("未设置附件条件时不应过滤")
+	}
+
+	invalid := map[string]*services.SentEmailQuery{
+		"收件人地址":   services.NewSentEmailQuery().Recipient("not-an-address"),
+		"空发件人":    services.NewSentEmailQuery().Sender(" "),
+		"空标题":     services.NewSentEmailQuery().SubjectContains(""),
+		"标题过长":    services.NewSentEmailQuery().SubjectContains(strings.Repeat("长", services.MaxSubjectFilterLen+1)),
+		"时间范围":    services.NewSentEmailQuery().SentBetween(to, from),
+		"分页大小":    services.NewSentEmailQuery().Limit(services.MaxSentEmailsPageSize + 1),
+		"第一个错误为准": services.NewSentEmailQuery().Recipient("bad").Sender("ok@example.com"),
+	}
+	for name, q := range invalid {
+		if _, err := q.Build(); !errors.Is(err, services.ErrInvalidQuery) {
+			t.Errorf("%s: 期望 ErrInvalidQuery，得到 %v", name, err)
+		}
+	}
+	if _, err := services.NewSentEmailQuery().Type("spam").Build(); !errors.Is(err, services.ErrInvalidEmailType) {
+		t.Errorf("期望 ErrInvalidEmailType，得到 %v", err)
+	}
+}
+
+// TestDeliveryStatus 测试按邮件ID获取邮件和投递状态
+func TestDeliveryStatus(t *testing.T) {
+	srv := &fakeEmailServer{deliveries: map[string]*email_client_pb.GetDeliveryStatusResponse{
+		"e1": {
+			EmailId: "e1",
+			State:   email_client_pb.DeliveryState_DELIVERY_STATE_BOUNCED,
+			Recipients: []*email_client_pb.RecipientDeliveryStatus{
+				{Recipient: "user@example.com", State: email_client_pb.DeliveryState_DELIVERY_STATE_BOUNCED, Attempts: 2},
+			},
+			Events: []*email_client_pb.DeliveryEvent{
+				{Recipient: "user@example.com", State: email_client_pb.DeliveryState_DELIVERY_STATE_DEFERRED, SmtpCode: 421, EnhancedCode: "4.7.0", SmtpResponse: "Try again later"},
+				{Recipient: "user@example.com", State: email_client_pb.DeliveryState_DELIVERY_STATE_BOUNCED, SmtpCode: 550, EnhancedCode: "5.1.1", SmtpResponse: "User unknown"},
+			},
+		},
+	}}
+	emailService := newTestEmailService(t, srv)
+	ctx := context.Background()
+
+	email, err := emailService.GetEmail(ctx, "e1")
+	if err != nil || email.GetEmail().GetId() != "e1" || email.GetState() != email_client_pb.DeliveryState_DELIVERY_STATE_BOUNCED {
+		t.Fatalf("获取邮件失败: %v %v", email, err)
+	}
+
+	delivery, err := emailService.GetDeliveryStatus(ctx, "e1")
+	if err != nil {
+		t.Fatalf("获取投递状态失败: %v", err)
+	}
+	if len(delivery.GetEvents()) != 2 || delivery.GetEvents()[1].GetSmtpCode() != 550 || delivery.GetRecipients()[0].GetAttempts() != 2 {
+		t.Errorf("投递时间线错误: %v", delivery)
+	}
+	if !services.IsFinalDeliveryState(delivery.GetState()) || services.IsFinalDeliveryState(email_client_pb.DeliveryState_DELIVERY_STATE_DEFERRED) {
+		t.Errorf("最终状态判断错误")
+	}
+
+	if _, err := emailService.GetDeliveryStatus(ctx, "missing"); status.Code(err) != codes.NotFound {
+		t.Errorf("邮件不存在时应返回 NotFound，得到 %v", err)
+	}
+	if _, err := emailService.GetEmail(ctx, " "); !errors.Is(err, services.ErrEmptyEmailID) {
+		t.Errorf("期望 ErrEmptyEmailID，得到 %v", err)
+	}
+}
+
+// TestExporter 测试导出已发送邮件以及中断后继续导出
+func TestExporter(t *testing.T) {
+	const total = 5
+	failAt := ""
+	srv := &fakeEmailServer{
+		getSentEmails: func(req *email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+			if req.GetCursor() != "" && req.GetCursor() == failAt {
+				return nil, status.Error(codes.Unavailable, "服务暂时不可用")
+			}
+			start := 0
+			if req.GetCursor() != "" {
+				fmt.Sscanf(req.GetCursor(), "c%d", &start)
+			}
+			end := min(start+int(req.GetLimit()), total)
+			resp := &email_client_pb.GetSentEmailsResponse{HasMore: end < total}
+			for i := start; i < end; i++ {
+				resp.Emails = append(resp.Emails, &email_client_pb.Email{
+					Id:        fmt.Sprintf("e%d", i),
+					Title:     fmt.Sprintf("订单确认 %d", i),
+					From:      "商城 <noreply@example.com>",
+					To:        []string{"user@example.com"},
+					TextBody:  "您好\nFrom the shop",
+					HtmlBody:  "<p>您好</p>",
+					EmailType: req.GetEmailType(),
+					SentAt:    timestamppb.New(time.Date(2024, 5, 1, 9, 0, i, 0, time.UTC)),
+					Attachments: []*email_client_pb.Attachment{
+						{Filename: "发票.pdf", Content: []byte("%PDF-1.4"), ContentType: "application/pdf"},
+					},
+				})
+			}
+			if resp.HasMore {
+				resp.NextCursor = fmt.Sprintf("c%d", end)
+			}
+			return resp, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+	ctx := context.Background()
+
+	t.Run("CSV与断点续传", func(t *testing.T) {
+		var checkpoints []export.Checkpoint
+		exporter := export.New(emailService, export.FormatCSV,
+			export.WithEmailType(services.EmailTypeNormal),
+			export.WithPageSize(2),
+			export.WithCheckpoint(func(cp export.Checkpoint) { checkpoints = append(checkpoints, cp) }),
+		)
+
+		var out strings.Builder
+		failAt = "c4"
+		cp, err := exporter.Export(ctx, &out, "")
+		if status.Code(err) != codes.Unavailable || cp.Cursor != "c4" || cp.Exported != 4 || cp.Done {
+			t.Fatalf("中断时应返回最后完成的检查点: %+v %v", cp, err)
+		}
+		if len(checkpoints) != 2 {
+			t.Errorf("每页应产生一个检查点: %+v", checkpoints)
+		}
+
+		failAt = ""
+		cp, err = exporter.Export(ctx, &out, cp.Cursor)
+		if err != nil || !cp.Done || cp.Exported != 1 {
+			t.Fatalf("继续导出失败: %+v %v", cp, err)
+		}
+
+		records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+		if err != nil {
+			t.Fatalf("CSV 格式错误: %v", err)
+		}
+		if len(records) != total+1 || records[0][0] != "id" || records[5][0] != "e4" {
+			t.Errorf("应只有一行表头且邮件不重复: %v", records)
+		}
+		if records[1][2] != services.EmailTypeNormal || records[1][11] != "发票.pdf" {
+			t.Errorf("CSV 字段错误: %v", records[1])
+		}
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		var out strings.Builder
+		if _, err := export.New(emailService, export.FormatJSONL).Export(ctx, &out, ""); err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != total {
+			t.Fatalf("期望 %d 行，得到 %d 行", total, len(lines))
+		}
+		email := &email_client_pb.Email{}
+		if err := protojson.Unmarshal([]byte(lines[0]), email); err != nil || email.GetId() != "e0" || len(email.GetAttachments()) != 1 {
+			t.Errorf("JSONL 内容错误: %v %v", email, err)
+		}
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		var out strings.Builder
+		if _, err := export.New(emailService, export.FormatMbox).Export(ctx, &out, ""); err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		mbox := out.String()
+		if !strings.HasPrefix(mbox, "From noreply@example.com Wed May  1 09:00:00 2024\n") {
+			t.Errorf("mbox 分隔行错误: %q", mbox[:60])
+		}
+		messages := strings.Split(mbox, "\n\nFrom noreply@example.com ")
+		if len(messages) != total || !strings.Contains(mbox, "\n>From the shop") {
+			t.Fatalf("应有 %d 封邮件且正文中的 From 行被转义", total)
+		}
+
+		// 每封邮件都是可解析的 MIME 邮件
+		raw := messages[0][strings.Index(messages[0], "\n")+1:]
+		msg, err := mail.ReadMessage(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("解析邮件失败: %v", err)
+		}
+		subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		if subject != "订单确认 0" || msg.Header.Get("X-Email-Id") != "e0" {
+			t.Errorf("邮件头错误: %v", msg.Header)
+		}
+		mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		if mediaType != "multipart/mixed" {
+			t.Fatalf("期望 multipart/mixed，得到 %s", mediaType)
+		}
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		var parts []string
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+			parts = append(parts, part.Header.Get("Content-Type"))
+			if part.FileName() == "发票.pdf" {
+				data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+				if string(data) != "%PDF-1.4" {
+					t.Errorf("附件内容错误: %q", data)
+				}
+			}
+		}
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "multipart/alternative") {
+			t.Errorf("邮件结构错误: %v", parts)
+		}
+	})
+
+	t.Run("mbox 缺少发送时间", func(t *testing.T) {
+		source := newTestEmailService(t, &fakeEmailServer{
+			getSentEmails: func(*email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+				return &email_client_pb.GetSentEmailsResponse{Emails: []*email_client_pb.Email{{Id: "e0", From: "a@example.com", Title: "草稿"}}}, nil
+			},
+		})
+		var out strings.Builder
+		if _, err := export.New(source, export.FormatMbox).Export(ctx, &out, ""); err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		line, _, _ := strings.Cut(out.String(), "\n")
+		exportedAt, err := time.Parse(time.ANSIC, strings.TrimPrefix(line, "From a@example.com "))
+		if err != nil || time.Since(exportedAt) > time.Minute {
+			t.Errorf("没有发送时间时分隔行应使用导出时间: %q", line)
+		}
+	})
+
+	if _, err := export.New(emailService, "xml").Export(ctx, io.Discard, ""); !errors.Is(err, export.ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，得到 %v", err)
+	}
+}
+
+// TestMIMERenderAndParse 测试邮件的 MIME 渲染以及 .eml 解析
+func TestMIMERenderAndParse(t *testing.T) {
+	original := &email_client_pb.Email{
+		Id:        "e1",
+		Title:     "您的订单已发货，预计明天送达，请注意查收包裹并保持电话畅通",
+		From:      "商城客服 <noreply@example.com>",
+		To:        []string{"张三 <zhangsan@example.com>", "lisi@example.com"},
+		Cc:        []string{"cc@example.com"},
+		Bcc:       []string{"audit@example.com"},
+		ReplyTo:   "support@example.com",
+		EmailType: services.EmailTypeNormal,
+		SentAt:    timestamppb.New(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)),
+		Headers:   map[string]string{"X-Campaign": "双十一"},
+		TextBody:  "您好，\n订单 A-1001 已发货。=完=",
+		HtmlBody:  "<p>您好，订单 <b>A-1001</b> 已发货。</p><img src=\"cid:logo\">",
+		Attachments: []*email_client_pb.Attachment{
+			{Filename: "发票.pdf", Content: bytes.Repeat([]byte{0, 1, 2, 0xff}, 100), ContentType: "application/pdf"},
+			{Filename: "logo.png", Content: []byte("png"), ContentType: "image/png", Disposition: email_client_pb.Attachment_INLINE, ContentId: "logo"},
+		},
+	}
+
+	raw, err := emailmime.Render(original)
+	if err != nil {
+		t.Fatalf("渲染失败: %v", err)
+	}
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("行长度超过 RFC 5322 限制: %d", len(line))
+		}
+		for _, r := range line {
+			if r > 127 {
+				t.Fatalf("渲染结果应只包含 ASCII 字符: %q", line)
+			}
+		}
+	}
+	if !strings.Contains(string(raw), "Subject: =?utf-8?q?") {
+		t.Errorf("中文标题应使用 RFC 2047 编码")
+	}
+	if strings.Contains(string(raw), "audit@example.com") {
+		t.Errorf("默认不应写入 Bcc 邮件头")
+	}
+	if archived, _ := emailmime.Render(original, emailmime.WithBcc()); !strings.Contains(string(archived), "\r\nBcc: <audit@example.com>\r\n") {
+		t.Errorf("WithBcc 应写入 Bcc 邮件头")
+	}
+
+	// 没有发送时间时 Date 使用当前时间
+	draft, err := emailmime.Render(&email_client_pb.Email{From: "a@example.com", To: []string{"b@example.com"}, Title: "草稿"})
+	if err != nil {
+		t.Fatalf("渲染失败: %v", err)
+	}
+	draftMsg, err := mail.ReadMessage(bytes.NewReader(draft))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if date, err := draftMsg.Header.Date(); err != nil || time.Since(date) > time.Minute {
+		t.Errorf("缺少发送时间时应使用当前时间作为 Date: %q", draftMsg.Header.Get("Date"))
+	}
+
+	// Message-ID 由邮件ID和发件人域名生成，每次渲染结果相同
+	if !strings.Contains(string(raw), "\r\nMessage-ID: <e1@example.com>\r\n") {
+		t.Errorf("应根据邮件ID生成 Message-ID")
+	}
+	if draftMsg.Header.Get("Message-ID") == "" {
+		t.Errorf("没有邮件ID时也应生成 Message-ID")
+	}
+
+	parsed, issues, err := emailmime.ParseWithIssues(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("渲染结果解析时不应有无法保留的内容: %v", issues)
+	}
+	if parsed.GetTitle() != original.GetTitle() || parsed.GetFrom() != original.GetFrom() || parsed.GetId() != "e1" {
+		t.Errorf("邮件头解析错误: %v", parsed)
+	}
+	if strings.Join(parsed.GetTo(), ",") != strings.Join(original.GetTo(), ",") || parsed.GetReplyTo() != original.GetReplyTo() {
+		t.Errorf("地址解析错误: %v", parsed.GetTo())
+	}
+	if !parsed.GetSentAt().AsTime().Equal(original.GetSentAt().AsTime()) || parsed.GetHeaders()["X-Campaign"] != "双十一" {
+		t.Errorf("日期或自定义邮件头错误: %v", parsed)
+	}
+	if parsed.GetTextBody() != original.GetTextBody() || parsed.GetHtmlBody() != original.GetHtmlBody() {
+		t.Errorf("正文解析错误: %q %q", parsed.GetTextBody(), parsed.GetHtmlBody())
+	}
+	if len(parsed.GetAttachments()) != 2 {
+		t.Fatalf("期望 2 个附件，得到 %d", len(parsed.GetAttachments()))
+	}
+	// 内嵌资源位于 multipart/related 中，解析后排在普通附件之前
+	for _, want := range original.GetAttachments() {
+		found := false
+		for _, a := range parsed.GetAttachments() {
+			if a.GetFilename() == want.GetFilename() {
+				found = bytes.Equal(a.GetContent(), want.GetContent()) && a.GetDisposition() == want.GetDisposition() && a.GetContentId() == want.GetContentId()
+			}
+		}
+		if !found {
+			t.Errorf("附件 %s 解析错误: %v", want.GetFilename(), parsed.GetAttachments())
+		}
+	}
+
+	// 其他邮件客户端生成的 GBK 编码邮件
+	gbk := "From: =?GBK?B?xOO6ww==?= <sender@example.com>\r\n" +
+		"To: user@example.com\r\n" +
+		"Subject: =?GBK?B?xOO6ww==?=\r\n" +
+		"Content-Type: text/plain; charset=GBK\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"=C4=E3=BA=C3\r\n"
+	parsed, err = emailmime.Parse(strings.NewReader(gbk))
+	if err != nil {
+		t.Fatalf("解析 GBK 邮件失败: %v", err)
+	}
+	if parsed.GetTitle() != "你好" || parsed.GetFrom() != "你好 <sender@example.com>" || strings.TrimSpace(parsed.GetTextBody()) != "你好" {
+		t.Errorf("GBK 字符集解码错误: %v", parsed)
+	}
+
+	if _, err := emailmime.Parse(strings.NewReader("not a message")); !errors.Is(err, emailmime.ErrInvalidMessage) {
+		t.Errorf("期望 ErrInvalidMessage，得到 %v", err)
+	}
+}
+
+// TestSendEML 测试解析并发送桌面邮件客户端导出的 .eml 邮件
+func TestSendEML(t *testing.T) {
+	eml := strings.ReplaceAll(`From: =?utf-8?b?5biC5Zy66YOo?= <marketing@example.com>
+To: user@example.com, =?utf-8?b?5byg5LiJ?= <zhangsan@example.com>
+Subject: =?utf-8?b?5Y+M5Y2B5LiA5aSn5L+D?=
+Message-ID: <abc@desktop.example.com>
+Date: Wed, 01 May 2024 09:00:00 +0800
+X-Mailer: Desktop Mail 1.0
+X-Evil: =?utf-8?q?a=0D=0ABcc:_victim@example.com?=
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="mixed"
+
+--mixed
+Content-Type: multipart/related; boundary="related"
+
+--related
+Content-Type: multipart/alternative; boundary="alt"
+
+--alt
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+=E5=A4=A7=E4=BF=83=E5=BC=80=E5=A7=8B
+--alt
+Content-Type: text/html; charset=utf-8
+
+<p>大促开始</p><img src="cid:banner@local">
+--alt
+Content-Type: text/calendar; charset=utf-8
+
+BEGIN:VCALENDAR
+END:VCALENDAR
+--alt--
+--related
+Content-Type: image/png
+Content-Transfer-Encoding: base64
+Content-ID: <banner@local>
+
+iVBORw0KGgo=
+--related--
+--mixed
+Content-Type: application/pdf; name="catalog.pdf"
+Content-Disposition: attachment; filename="catalog.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0xLjQ=
+--mixed--
+`, "\n", "\r\n")
+
+	var sent *email_client_pb.SendEmailRequest
+	srv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			sent = req
+			return &email_client_pb.SendEmailResponse{Success: true, EmailId: "e1"}, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+
+	result, err := emailService.SendEML(context.Background(), strings.NewReader(eml), "config", services.WithBcc("audit@example.com"))
+	if err != nil {
+		t.Fatalf("发送 .eml 失败: %v", err)
+	}
+	if !result.GetSuccess() || result.GetEmailId() != "e1" {
+		t.Errorf("发送结果错误: %v", result.SendEmailResponse)
+	}
+
+	email := sent.GetEmail()
+	if email.GetTitle() != "双十一大促" || email.GetFrom() != "市场部 <marketing@example.com>" || sent.GetConfigId() != "config" {
+		t.Errorf("邮件头映射错误: %v", email)
+	}
+	if strings.Join(email.GetTo(), ",") != "user@example.com,zhangsan@example.com" || len(email.GetBcc()) != 1 {
+		t.Errorf("收件人映射错误: %v %v", email.GetTo(), email.GetBcc())
+	}
+	if email.GetTextBody() != "大促开始" || !strings.Contains(email.GetHtmlBody(), "cid:banner@local") {
+		t.Errorf("正文映射错误: %q %q", email.GetTextBody(), email.GetHtmlBody())
+	}
+	if email.GetHeaders()["X-Mailer"] != "Desktop Mail 1.0" || email.GetHeaders()["X-Evil"] != "" {
+		t.Errorf("自定义邮件头映射错误: %v", email.GetHeaders())
+	}
+
+	var inline, pdf, calendar *email_client_pb.Attachment
+	for _, a := range email.GetAttachments() {
+		switch {
+		case a.GetContentId() == "banner@local":
+			inline = a
+		case a.GetFilename() == "catalog.pdf":
+			pdf = a
+		case a.GetContentType() == "text/calendar":
+			calendar = a
+		}
+	}
+	if inline == nil || inline.GetDisposition() != email_client_pb.Attachment_INLINE || pdf == nil || string(pdf.GetContent()) != "%PDF-1.4" || calendar == nil {
+		t.Errorf("附件映射错误: %v", email.GetAttachments())
+	}
+
+	// 无法表示的内容都应出现在问题列表中
+	parts := make(map[string]bool)
+	for _, issue := range result.Issues {
+		parts[issue.Part] = true
+	}
+	for _, part := range []string{"Message-Id", "X-Evil", "1.1.3"} {
+		if !parts[part] {
+			t.Errorf("问题列表中缺少 %s: %v", part, result.Issues)
+		}
+	}
+
+	if _, err := emailService.SendEML(context.Background(), strings.NewReader("Subject: 无收件人\r\nFrom: a@example.com\r\n\r\nbody"), "config"); !errors.Is(err, services.ErrNoRecipients) {
+		t.Errorf("期望 ErrNoRecipients，得到 %v", err)
+	}
+}
+
+// TestRecipientValidation 测试发送前的收件人地址校验与规范化
+func TestRecipientValidation(t *testing.T) {
+	for input, want := range map[string]string{
+		"user@example.com":                "user@example.com",
+		"张三 <User@EXAMPLE.com>":           "User@example.com",
+		"Bob <bob@例子.中国>":                 "bob@xn--fsqu00a.xn--fiqs8s",
+		"  alice@Bücher.example  ":        "alice@xn--bcher-kva.example",
+		"\"quoted name\" <q@example.com>": "q@example.com",
+	} {
+		got, err := services.NormalizeAddress(input)
+		if err != nil || got != want {
+			t.Errorf("NormalizeAddress(%q) = %q, %v，期望 %q", input, got, err, want)
+		}
+	}
+
+	var sent []*email_client_pb.SendEmailRequest
+	srv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			sent = append(sent, req)
+			return &email_client_pb.SendEmailResponse{Success: true}, nil
+		},
+		sendEmails: func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+			return &email_client_pb.SendEmailsResponse{Success: true}, nil
+		},
+	}
+	emailService := newTestEmailService(t, srv)
+	ctx := context.Background()
+
+	// 规范化并去重，域名不区分大小写，抄送中与收件人重复的地址被去掉
+	original := &email_client_pb.Email{
+		From: "noreply@example.com",
+		To:   []string{"张三 <zhang@Example.COM>", "zhang@example.com", "li@例子.中国"},
+		Cc:   []string{"ZHANG@example.com", "li@xn--fsqu00a.xn--fiqs8s"},
+	}
+	_, err := emailService.SendEmail(ctx, &email_client_pb.SendEmailRequest{Email: original, ConfigId: "config"})
+	if err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	email := sent[0].GetEmail()
+	if strings.Join(email.GetTo(), ",") != "zhang@example.com,li@xn--fsqu00a.xn--fiqs8s" || strings.Join(email.GetCc(), ",") != "ZHANG@example.com" {
+		t.Errorf("收件人规范化错误: to=%v cc=%v", email.GetTo(), email.GetCc())
+	}
+	if len(original.GetTo()) != 3 || original.GetTo()[0] != "张三 <zhang@Example.COM>" {
+		t.Errorf("规范化不应修改调用方的邮件: %v", original.GetTo())
+	}
+
+	// 不合法的地址在发起请求前返回，并列出所有不合法的地址
+	_, err = emailService.SendEmail(ctx, &email_client_pb.SendEmailRequest{
+		Email: &email_client_pb.Email{
+			To:  []string{"bob@@example.com", "ok@example.com"},
+			Bcc: []string{"a@b.com, c@d.com", "x@-bad-.com"},
+		},
+	})
+	var recipientErr *services.InvalidRecipientError
+	if !errors.As(err, &recipientErr) {
+		t.Fatalf("期望 InvalidRecipientError，得到 %v", err)
+	}
+	if got := recipientErr.Addresses(); len(got) != 3 || got[0] != "bob@@example.com" || recipientErr.Invalid[1].Field != "Bcc" {
+		t.Errorf("不合法地址列表错误: %v", recipientErr.Invalid)
+	}
+	if len(sent) != 1 {
+		t.Errorf("地址不合法时不应发起请求")
+	}
+
+	// 批量发送时指出出错的邮件
+	_, err = emailService.SendEmails(ctx, &email_client_pb.SendEmailsRequest{Emails: []*email_client_pb.Email{
+		{To: []string{"ok@example.com"}},
+		{To: []string{"not an address"}},
+	}})
+	if !errors.As(err, &recipientErr) || !strings.Contains(err.Error(), "第 1 封邮件") {
+		t.Errorf("批量发送时应返回出错邮件的序号: %v", err)
+	}
+
+	emailService.SetMaxRecipients(2)
+	_, err = emailService.SendEmail(ctx, &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{
+		To: []string{"a@example.com", "A@EXAMPLE.com"},
+		Cc: []string{"b@example.com", "c@example.com"},
+	}})
+	if !errors.Is(err, services.ErrTooManyRecipients) {
+		t.Errorf("期望 ErrTooManyRecipients，得到 %v", err)
+	}
+}
+
+// fakeSuppressionServer 是用于测试的内存抑制列表服务端
+type fakeSuppressionServer struct {
+	email_client_pb.UnimplementedSuppressionServiceServer
+	mu           sync.Mutex
+	suppressions []*email_client_pb.Suppression
+	listCalls    int
+	lastLimit    int32 // 最近一次 ListSuppressions 请求的分页大小
+	listErr      error // 不为空时 ListSuppressions 返回该错误
+}
+
+func (s *fakeSuppressionServer) AddSuppression(_ context.Context, req *email_client_pb.AddSuppressionRequest) (*email_client_pb.SuppressionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suppressions = append(s.suppressions, req.GetSuppression())
+	return &email_client_pb.SuppressionResponse{Success: true, Suppression: req.GetSuppression()}, nil
+}
+
+func (s *fakeSuppressionServer) RemoveSuppression(_ context.Context, req *email_client_pb.RemoveSuppressionRequest) (*email_client_pb.RemoveSuppressionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, suppression := range s.suppressions {
+		if suppression.GetAddress() == req.GetAddress() {
+			s.suppressions = append(s.suppressions[:i], s.suppressions[i+1:]...)
+			return &email_client_pb.RemoveSuppressionResponse{Success: true}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "地址不在抑制列表中")
+}
+
+func (s *fakeSuppressionServer) CheckSuppression(_ context.Context, req *email_client_pb.CheckSuppressionRequest) (*email_client_pb.CheckSuppressionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &email_client_pb.CheckSuppressionResponse{}
+	for _, addr := range req.GetAddresses() {
+		for _, suppression := range s.suppressions {
+			if strings.EqualFold(suppression.GetAddress(), addr) {
+				resp.Suppressions = append(resp.Suppressions, suppression)
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakeSuppressionServer) ListSuppressions(_ context.Context, req *email_client_pb.ListSuppressionsRequest) (*email_client_pb.ListSuppressionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listCalls++
+	s.lastLimit = req.GetLimit()
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var start int
+	if req.GetCursor() != "" {
+		fmt.Sscan(req.GetCursor(), &start)
+	}
+	end := min(start+int(req.GetLimit()), len(s.suppressions))
+	resp := &email_client_pb.ListSuppressionsResponse{Suppressions: s.suppressions[start:end]}
+	if end < len(s.suppressions) {
+		resp.HasMore = true
+		resp.NextCursor = fmt.Sprint(end)
+	}
+	return resp, nil
+}
+
+// newTestSuppressionService 启动内存中的抑制列表服务端，返回连接到它的客户端
+func newTestSuppressionService(t *testing.T, srv email_client_pb.SuppressionServiceServer) *services.SuppressionServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	email_client_pb.RegisterSuppressionServiceServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("无法连接测试服务端: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// 分页大小为 2，确保刷新缓存时需要获取多页
+	return services.NewSuppressionServiceClient(conn, 5*time.Second, 2, false)
+}
+
+// fakeTemplateServer 是用于测试的模板服务端，记录收到的模板邮件发送请求
+type fakeTemplateServer struct {
+	email_client_pb.UnimplementedTemplateServiceServer
+	sent []*email_client_pb.SendTemplatedEmailRequest
+}
+
+func (s *fakeTemplateServer) SendTemplatedEmail(_ context.Context, req *email_client_pb.SendTemplatedEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+	s.sent = append(s.sent, req)
+	return &email_client_pb.SendEmailResponse{Success: true}, nil
+}
+
+// newTestTemplateService 启动内存中的模板服务端，返回连接到它的客户端
+func newTestTemplateService(t *testing.T, srv email_client_pb.TemplateServiceServer) *services.TemplateServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	email_client_pb.RegisterTemplateServiceServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("无法连接测试服务端: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return services.NewTemplateServiceClient(conn, 5*time.Second, 20, false)
+}
+
+// TestSuppression 测试抑制列表管理以及发送前的收件人过滤
+func TestSuppression(t *testing.T) {
+	ctx := context.Background()
+	suppressionSrv := &fakeSuppressionServer{suppressions: []*email_client_pb.Suppression{
+		{Address: "bounced@example.com", Reason: email_client_pb.SuppressionReason_SUPPRESSION_REASON_HARD_BOUNCE},
+		{Address: "complaint@example.com", Reason: email_client_pb.SuppressionReason_SUPPRESSION_REASON_COMPLAINT},
+		{Address: "expired@example.com", Reason: email_client_pb.SuppressionReason_SUPPRESSION_REASON_MANUAL, ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour))},
+	}}
+	suppressionService := newTestSuppressionService(t, suppressionSrv)
+
+	// 地址在加入和检查时都会被规范化
+	if _, err := suppressionService.Suppress(ctx, "退订 <Unsub@Example.COM>", email_client_pb.SuppressionReason_SUPPRESSION_REASON_UNSUBSCRIBE, time.Time{}); err != nil {
+		t.Fatalf("加入抑制列表失败: %v", err)
+	}
+	if got := suppressionSrv.suppressions[3].GetAddress(); got != "Unsub@example.com" {
+		t.Errorf("加入抑制列表的地址未规范化: %q", got)
+	}
+	if _, err := suppressionService.Suppress(ctx, "not an address", email_client_pb.SuppressionReason_SUPPRESSION_REASON_MANUAL, time.Time{}); err == nil {
+		t.Error("不合法的地址应返回错误")
+	}
+	checked, err := suppressionService.CheckSuppression(ctx, "unsub@EXAMPLE.com", "ok@example.com")
+	if err != nil {
+		t.Fatalf("检查抑制列表失败: %v", err)
+	}
+	if len(checked) != 1 || checked["unsub@example.com"].GetReason() != email_client_pb.SuppressionReason_SUPPRESSION_REASON_UNSUBSCRIBE {
+		t.Errorf("检查结果错误: %v", checked)
+	}
+
+	var sent []*email_client_pb.Email
+	var batches []*email_client_pb.SendEmailsRequest
+	emailSrv := &fakeEmailServer{
+		sendEmail: func(req *email_client_pb.SendEmailRequest) (*email_client_pb.SendEmailResponse, error) {
+			sent = append(sent, req.GetEmail())
+			return &email_client_pb.SendEmailResponse{Success: true}, nil
+		},
+		sendEmails: func(req *email_client_pb.SendEmailsRequest) (*email_client_pb.SendEmailsResponse, error) {
+			batches = append(batches, req)
+			resp := &email_client_pb.SendEmailsResponse{Success: true}
+			for i := range req.GetEmails() {
+				resp.Results = append(resp.Results, &email_client_pb.SendResult{Index: int32(i), EmailId: fmt.Sprintf("id-%d", i)})
+			}
+			return resp, nil
+		},
+	}
+	emailService := newTestEmailService(t, emailSrv)
+
+	var filtered []string
+	cache := services.NewSuppressionCache(suppressionService, services.WithSuppressedHandler(func(_ *email_client_pb.Email, removed []*email_client_pb.Suppression) {
+		for _, s := range removed {
+			filtered = append(filtered, s.GetAddress())
+		}
+	}))
+	emailService.SetSuppressionFilter(cache)
+
+	// 被抑制的收件人被移除，已过期的抑制记录不生效，地址匹配不区分大小写
+	original := &email_client_pb.Email{
+		To:  []string{"ok@example.com", "Bounced@Example.com"},
+		Cc:  []string{"expired@example.com"},
+		Bcc: []string{"unsub@example.com"},
+	}
+	_, err = emailService.SendEmail(ctx, &email_client_pb.SendEmailRequest{Email: original})
+	if err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(original.GetTo()) != 2 || len(original.GetBcc()) != 1 {
+		t.Errorf("调用方的邮件不应被修改: to=%v bcc=%v", original.GetTo(), original.GetBcc())
+	}
+	if email := sent[0]; strings.Join(email.GetTo(), ",") != "ok@example.com" || len(email.GetCc()) != 1 || len(email.GetBcc()) != 0 {
+		t.Errorf("过滤结果错误: to=%v cc=%v bcc=%v", email.GetTo(), email.GetCc(), email.GetBcc())
+	}
+	if strings.Join(filtered, ",") != "bounced@example.com,Unsub@example.com" {
+		t.Errorf("回调收到的抑制记录错误: %v", filtered)
+	}
+	if cache.Len() != 4 || suppressionSrv.listCalls != 1 || suppressionSrv.lastLimit != 1000 {
+		t.Errorf("缓存应以最大分页一次加载全部 4 条记录，得到 %d 条，%d 次请求，分页大小 %d", cache.Len(), suppressionSrv.listCalls, suppressionSrv.lastLimit)
+	}
+
+	// 所有收件人都被抑制时不发送
+	_, err = emailService.SendEmail(ctx, &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{To: []string{"complaint@example.com"}}})
+	if !errors.Is(err, services.ErrAllRecipientsSuppressed) || len(sent) != 1 {
+		t.Errorf("期望 ErrAllRecipientsSuppressed 且不发送，得到 %v", err)
+	}
+	if suppressionSrv.listCalls != 1 {
+		t.Errorf("刷新间隔内不应重新加载抑制列表")
+	}
+
+	// 批量发送时跳过所有收件人都被抑制的邮件，结果下标对应原请求
+	batch, err := emailService.SendEmails(ctx, &email_client_pb.SendEmailsRequest{Emails: []*email_client_pb.Email{
+		{Title: "a", To: []string{"complaint@example.com"}},
+		{Title: "b", To: []string{"ok@example.com", "bounced@example.com"}},
+		{Title: "c", To: []string{"other@example.com"}},
+	}})
+	if err != nil {
+		t.Fatalf("批量发送失败: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].GetEmails()) != 2 || batches[0].GetIdempotencyKey() != batch.Request.GetIdempotencyKey() {
+		t.Fatalf("实际发送的请求错误: %v", batches)
+	}
+	results := batch.GetResults()
+	if batch.GetSuccess() || len(results) != 3 ||
+		codes.Code(results[0].GetCode()) != codes.FailedPrecondition ||
+		results[1].GetEmailId() != "id-0" || results[2].GetIndex() != 2 || results[2].GetEmailId() != "id-1" {
+		t.Errorf("批量发送结果错误: %v", results)
+	}
+	if failed := batch.FailedEmails(); len(failed) != 1 || failed[0].GetTitle() != "a" {
+		t.Errorf("失败的邮件错误: %v", failed)
+	}
+
+	// 流式发送同样过滤被抑制的收件人，所有收件人都被抑制的邮件直接返回 FailedPrecondition
+	input := []*email_client_pb.Email{
+		{Title: "a", To: []string{"ok@example.com", "bounced@example.com"}},
+		{Title: "b", To: []string{"complaint@example.com"}},
+	}
+	emails := make(chan *email_client_pb.Email, len(input))
+	for _, email := range input {
+		emails <- email
+	}
+	close(emails)
+	stream, err := emailService.SendStream(ctx, "config", emails)
+	if err != nil {
+		t.Fatalf("创建发送流失败: %v", err)
+	}
+	byIndex := make(map[int32]*email_client_pb.SendResult)
+	for result := range stream {
+		if result.Err != nil {
+			t.Fatalf("发送流异常结束: %v", result.Err)
+		}
+		byIndex[result.Event.GetResult().GetIndex()] = result.Event.GetResult()
+	}
+	if byIndex[0].GetEmailId() != "id-a" || codes.Code(byIndex[1].GetCode()) != codes.FailedPrecondition {
+		t.Errorf("流式发送事件错误: %v", byIndex)
+	}
+	if len(emailSrv.streamed) != 1 || strings.Join(emailSrv.streamed[0].GetTo(), ",") != "ok@example.com" || len(input[0].GetTo()) != 2 {
+		t.Errorf("流式发送过滤结果错误: %v", emailSrv.streamed)
+	}
+
+	// 模板邮件在请求的副本上过滤收件人
+	templateSrv := &fakeTemplateServer{}
+	templateService := newTestTemplateService(t, templateSrv)
+	templateService.SetSuppressionFilter(cache)
+	templated := &email_client_pb.SendTemplatedEmailRequest{
+		TemplateId: "welcome",
+		To:         []string{"ok@example.com"},
+		Cc:         []string{"bounced@example.com"},
+	}
+	if _, err := templateService.SendTemplated(ctx, templated); err != nil {
+		t.Fatalf("发送模板邮件失败: %v", err)
+	}
+	if len(templateSrv.sent) != 1 || len(templateSrv.sent[0].GetCc()) != 0 || len(templated.GetCc()) != 1 {
+		t.Errorf("模板邮件过滤结果错误: %v", templateSrv.sent)
+	}
+	_, err = templateService.SendTemplated(ctx, &email_client_pb.SendTemplatedEmailRequest{TemplateId: "welcome", To: []string{"complaint@example.com"}})
+	if !errors.Is(err, services.ErrAllRecipientsSuppressed) || len(templateSrv.sent) != 1 {
+		t.Errorf("期望 ErrAllRecipientsSuppressed 且不发送模板邮件，得到 %v", err)
+	}
+
+	// 从本地缓存移除后立即生效
+	complaint := &email_client_pb.SendEmailRequest{Email: &email_client_pb.Email{To: []string{"complaint@example.com"}}}
+	cache.Remove("complaint@example.com")
+	if _, err := emailService.SendEmail(ctx, complaint); err != nil {
+		t.Errorf("移除后应可以发送: %v", err)
+	}
+
+	// Invalidate 后的下一次发送不等待刷新，使用现有缓存，刷新在后台进行
+	cache.Invalidate()
+	if _, err := emailService.SendEmail(ctx, complaint); err != nil {
+		t.Errorf("后台刷新期间应使用现有缓存发送: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for _, ok := cache.Lookup("complaint@example.com"); !ok && time.Now().Before(deadline); _, ok = cache.Lookup("complaint@example.com") {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := emailService.SendEmail(ctx, complaint); !errors.Is(err, services.ErrAllRecipientsSuppressed) {
+		t.Errorf("重新加载后地址应再次被抑制，得到 %v", err)
+	}
+
+	// 关联缓存后，通过服务增删地址立即同步到缓存；规范化作用于请求的副本
+	suppressionService.SetCache(cache)
+	addReq := &email_client_pb.AddSuppressionRequest{Suppression: &email_client_pb.Suppression{Address: "新用户 <New@Example.COM>"}}
+	if _, err := suppressionService.AddSuppression(ctx, addReq); err != nil {
+		t.Fatalf("加入抑制列表失败: %v", err)
+	}
+	if addReq.GetSuppression().GetAddress() != "新用户 <New@Example.COM>" {
+		t.Errorf("调用方的请求不应被修改: %v", addReq.GetSuppression().GetAddress())
+	}
+	if _, ok := cache.Lookup("new@example.com"); !ok {
+		t.Errorf("加入抑制列表后应立即同步到缓存")
+	}
+	if _, err := suppressionService.RemoveSuppression(ctx, "New@example.com"); err != nil {
+		t.Fatalf("移出抑制列表失败: %v", err)
+	}
+	if _, ok := cache.Lookup("new@example.com"); ok {
+		t.Errorf("移出抑制列表后应立即从缓存中删除")
+	}
+
+	// 第一次加载失败时默认照常发送，启用 WithFailClosed 后拒绝发送
+	failingSrv := &fakeSuppressionServer{listErr: status.Error(codes.Unavailable, "服务暂时不可用")}
+	failingService := newTestSuppressionService(t, failingSrv)
+	emailService.SetSuppressionFilter(services.NewSuppressionCache(failingService))
+	if _, err := emailService.SendEmail(ctx, complaint); err != nil {
+		t.Errorf("抑制列表不可用时默认应照常发送: %v", err)
+	}
+	sentBefore := len(sent)
+	emailService.SetSuppressionFilter(services.NewSuppressionCache(failingService, services.WithFailClosed()))
+	_, err = emailService.SendEmail(ctx, complaint)
+	if !errors.Is(err, services.ErrSuppressionListUnavailable) || len(sent) != sentBefore {
+		t.Errorf("期望 ErrSuppressionListUnavailable 且不发送，得到 %v", err)
+	}
+	_, err = emailService.SendEmails(ctx, &email_client_pb.SendEmailsRequest{Emails: []*email_client_pb.Email{complaint.GetEmail()}})
+	if !errors.Is(err, services.ErrSuppressionListUnavailable) || len(batches) != 1 {
+		t.Errorf("批量发送期望 ErrSuppressionListUnavailable 且不发送，得到 %v", err)
+	}
+}
+
+// newTestEmailClient 启动内存中的邮件服务端，返回通过连接管理器连接到它的完整客户端
+func newTestEmailClient(t *testing.T, srv email_client_pb.EmailServiceServer, opts ...client.Option) *client.EmailClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	email_client_pb.RegisterEmailServiceServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts = append([]client.Option{
+		client.DisableHealthCheck(),
+		client.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+	}, opts...)
+	emailClient, err := client.NewEmailClient("passthrough:///bufnet", 5*time.Second, 20, false, opts...)
+	if err != nil {
+		t.Fatalf("无法连接测试服务端: %v", err)
+	}
+	t.Cleanup(func() { emailClient.Close() })
+	return emailClient
+}
+
+// TestInterceptorChain 测试内置拦截器链的安装顺序与请求指标
+func TestInterceptorChain(t *testing.T) {
+	ctx := context.Background()
+	var serverCalls, failures int
+	srv := &fakeEmailServer{
+		getSentEmails: func(*email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+			serverCalls++
+			if failures != 0 {
+				failures--
+				return nil, status.Error(codes.Unavailable, "服务暂时不可用")
+			}
+			return &email_client_pb.GetSentEmailsResponse{}, nil
+		},
+	}
+
+	// 默认不重试
+	failures = 1
+	defaultClient := newTestEmailClient(t, srv)
+	if _, err := defaultClient.EmailService().GetAllSentEmails(ctx, "", 0); status.Code(err) != codes.Unavailable || serverCalls != 1 {
+		t.Fatalf("默认不应重试: err=%v, 调用 %d 次", err, serverCalls)
+	}
+	if stats := defaultClient.Metrics(); stats.RequestCount != 1 || stats.RequestFailureCount != 1 {
+		t.Errorf("指标统计错误: %+v", stats)
+	}
+
+	var userCalls int
+	emailClient := newTestEmailClient(t, srv,
+		client.WithRateLimiterConfig(client.RateLimiterConfig{RequestsPerSecond: 0.001, MaxBurst: 3}),
+		client.WithCircuitBreakerConfig(client.CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Hour, HalfOpenMaxRequests: 1}),
+		client.WithRetryConfig(client.RetryConfig{MaxRetries: 2, RetryPolicy: func(int) time.Duration { return time.Millisecond }}),
+		client.WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			userCalls++
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	emailService := emailClient.EmailService()
+
+	// 重试在自定义拦截器之外，每次尝试都经过自定义拦截器，指标只记录一次逻辑调用
+	serverCalls, failures = 0, 2
+	if _, err := emailService.GetAllSentEmails(ctx, "", 0); err != nil {
+		t.Fatalf("重试后应成功: %v", err)
+	}
+	if serverCalls != 3 || userCalls != 3 {
+		t.Errorf("期望服务端和自定义拦截器各调用 3 次，得到 %d 和 %d 次", serverCalls, userCalls)
+	}
+	if stats := emailClient.Metrics(); stats.RequestCount != 1 || stats.RequestSuccessCount != 1 {
+		t.Errorf("指标统计错误: %+v", stats)
+	}
+
+	// 断路器在重试之外，重试耗尽后只记录一次失败并开路
+	failures = -1
+	if _, err := emailService.GetAllSentEmails(ctx, "", 0); status.Code(err) != codes.Unavailable {
+		t.Fatalf("期望 Unavailable，得到 %v", err)
+	}
+	if userCalls != 6 || emailClient.CircuitBreaker().State() != middleware.CircuitOpen {
+		t.Errorf("期望自定义拦截器调用 6 次且断路器开路，得到 %d 次，%s", userCalls, emailClient.CircuitBreaker().State())
+	}
+
+	// 断路器开路时请求不会到达指标收集和自定义拦截器
+	var openErr *client.CircuitOpenError
+	if _, err := emailService.GetAllSentEmails(ctx, "", 0); !errors.As(err, &openErr) || status.Code(err) != codes.Unavailable {
+		t.Fatalf("期望状态码为 Unavailable 的 CircuitOpenError，得到 %v", err)
+	}
+	if stats := emailClient.Metrics(); userCalls != 6 || stats.RequestCount != 2 || stats.RequestFailureCount != 1 {
+		t.Errorf("断路器开路后不应继续调用: 自定义拦截器 %d 次, 指标 %+v", userCalls, stats)
+	}
+
+	// 速率限制在最外层：每次逻辑调用（包括被断路器拒绝的）消耗一个令牌，重试不消耗令牌
+	var rateErr *client.RateLimitExceededError
+	if _, err := emailService.GetAllSentEmails(ctx, "", 0); !errors.As(err, &rateErr) {
+		t.Errorf("期望 RateLimitExceededError，得到 %v", err)
+	}
+}
+
+// TestCircuitBreakerCancellation 测试调用方取消的请求不计入断路器失败次数
+func TestCircuitBreakerCancellation(t *testing.T) {
+	ctx := context.Background()
+	cb := middleware.NewCircuitBreaker(middleware.CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Millisecond, HalfOpenMaxRequests: 1}, false)
+	interceptor := middleware.CircuitBreakerInterceptor(cb)
+	invoke := func(err error) error {
+		return interceptor(ctx, "/test", nil, nil, nil, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return err
+		})
+	}
+
+	// 重试等待期间被取消时返回的是未包装的 context.Canceled
+	invoke(context.Canceled)
+	invoke(status.Error(codes.Canceled, "请求已取消"))
+	if cb.State() != middleware.CircuitClosed {
+		t.Fatalf("取消的请求不应使断路器开路")
+	}
+
+	invoke(status.Error(codes.Unavailable, "服务暂时不可用"))
+	if cb.State() != middleware.CircuitOpen {
+		t.Fatalf("服务不可用应使断路器开路")
+	}
+
+	// 半开状态下被取消的探测请求不占用名额，下一个请求仍可通过
+	time.Sleep(5 * time.Millisecond)
+	if err := invoke(context.Canceled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("半开状态应允许探测请求通过，得到 %v", err)
+	}
+	if err := invoke(nil); err != nil || cb.State() != middleware.CircuitClosed {
+		t.Errorf("探测请求成功后断路器应关闭: err=%v, state=%s", err, cb.State())
+	}
+}
+
+// TestCustomInterceptors 测试自定义拦截器在每次重试时重新执行，且拦截器和拨号选项在重连后依然生效
+func TestCustomInterceptors(t *testing.T) {
+	ctx := context.Background()
+	var failures int
+	srv := &fakeEmailServer{
+		getSentEmails: func(*email_client_pb.GetSentEmailsRequest) (*email_client_pb.GetSentEmailsResponse, error) {
+			if failures > 0 {
+				failures--
+				return nil, status.Error(codes.Unavailable, "服务暂时不可用")
+			}
+			return &email_client_pb.GetSentEmailsResponse{}, nil
+		},
+	}
+
+	var order []string
+	var streamCalls int
+	emailClient := newTestEmailClient(t, srv,
+		client.WithRetryConfig(client.RetryConfig{MaxRetries: 1, RetryPolicy: func(int) time.Duration { return time.Millisecond }}),
+		client.WithUnaryInterceptors(
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				order = append(order, "auth")
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				order = append(order, "trace")
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		),
+		client.WithStreamInterceptors(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			streamCalls++
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	)
+	emailService := emailClient.EmailService()
+
+	call := func() {
+		t.Helper()
+		failures = 1
+		if _, err := emailService.GetAllSentEmails(ctx, "", 0); err != nil {
+			t.Fatalf("调用失败: %v", err)
+		}
+	}
+	stream := func() {
+		t.Helper()
+		emails := make(chan *email_client_pb.Email)
+		close(emails)
+		results, err := emailService.SendStream(ctx, "config", emails)
+		if err != nil {
+			t.Fatalf("创建发送流失败: %v", err)
+		}
+		for range results {
+		}
+	}
+
+	// 一次失败后重试成功，自定义拦截器按传入顺序执行两轮
+	call()
+	stream()
+	if strings.Join(order, ",") != "auth,trace,auth,trace" || streamCalls != 1 {
+		t.Errorf("自定义拦截器调用错误: unary=%v stream=%d", order, streamCalls)
+	}
+
+	// 重连后使用新的连接，测试服务端只能通过自定义拨号选项连接，因此调用成功说明拨号选项依然生效
+	oldConn := emailClient.GetConnManager().GetConn()
+	if err := emailClient.GetConnManager().Reconnect(ctx, ""); err != nil {
+		t.Fatalf("重连失败: %v", err)
+	}
+	if emailClient.GetConnManager().GetConn() == oldConn {
+		t.Fatal("重连后应使用新的连接")
+	}
+	order, streamCalls = nil, 0
+	call()
+	stream()
+	if strings.Join(order, ",") != "auth,trace,auth,trace" || streamCalls != 1 {
+		t.Errorf("重连后自定义拦截器调用错误: unary=%v stream=%d", order, streamCalls)
+	}
+}
+
+// TestUploadAttachment 测试客户端流式分块上传附件
+func TestUploadAttachment(t *testing.T) {
+	srv := &fakeEmailServer{}
+	emailService := newTestEmailService(t, srv)
+	emailService.SetUploadChunkSize(4)
+	ctx := context.Background()
+
+	attachment, err := emailService.UploadAttachment(ctx, "notes.txt", "", 10, strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if attachment.GetAttachmentId() != "att-notes.txt" || attachment.GetSize() != 10 || !strings.HasPrefix(attachment.GetContentType(), "text/plain") {
+		t.Errorf("返回的附件错误: %v", attachment)
+	}
+
+	// 元数据最先发送，数据块按块大小拆分
+	if meta := srv.uploadMetadata; meta.GetFilename() != "notes.txt" || meta.GetSize() != 10 || meta.GetContentType() != attachment.GetContentType() {
+		t.Errorf("元数据错误: %v", meta)
+	}
+	var sizes []int
+	for _, chunk := range srv.uploadChunks {
+		sizes = append(sizes, len(chunk))
+	}
+	if fmt.Sprint(sizes) != "[4 4 2]" || string(bytes.Join(srv.uploadChunks, nil)) != "0123456789" {
+		t.Errorf("数据块拆分错误: %v", sizes)
+	}
+
+	// 内容长度正好是块大小的整数倍时不发送空数据块
+	if _, err := emailService.UploadAttachment(ctx, "even.bin", "application/octet-stream", 0, bytes.NewReader(make([]byte, 8))); err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if len(srv.uploadChunks) != 2 || srv.uploadMetadata.GetContentType() != "application/octet-stream" {
+		t.Errorf("期望 2 个数据块，得到 %d 个", len(srv.uploadChunks))
+	}
+
+	// 服务端接收的字节数与发送的不一致时返回错误
+	srv.uploadSizeOffset = -1
+	if _, err := emailService.UploadAttachment(ctx, "short.txt", "", 0, strings.NewReader("hello")); err == nil || !strings.Contains(err.Error(), "大小不一致") {
+		t.Errorf("期望大小不一致错误，得到 %v", err)
+	}
+}
+
 // 辅助函数：创建模拟邮件
 func createMockEmail(title, emailType string) *email_client_pb.Email {
 	return &email_client_pb.Email{
@@ -9,6 +9,7 @@ package email_client_pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,6 +23,170 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DeliveryState 邮件的投递状态
+type DeliveryState int32
+
+const (
+	DeliveryState_DELIVERY_STATE_UNSPECIFIED DeliveryState = 0 // 未知状态，旧版服务端不返回投递状态
+	DeliveryState_DELIVERY_STATE_QUEUED      DeliveryState = 1 // 已进入发送队列
+	DeliveryState_DELIVERY_STATE_SENDING     DeliveryState = 2 // 正在投递
+	DeliveryState_DELIVERY_STATE_DEFERRED    DeliveryState = 3 // 收件服务器返回临时错误(4xx)，稍后重试
+	DeliveryState_DELIVERY_STATE_DELIVERED   DeliveryState = 4 // 收件服务器已接受
+	DeliveryState_DELIVERY_STATE_BOUNCED     DeliveryState = 5 // 收件服务器返回永久错误(5xx)或产生退信
+	DeliveryState_DELIVERY_STATE_FAILED      DeliveryState = 6 // 重试次数用尽或发送前出错
+)
+
+// Enum value maps for DeliveryState.
+var (
+	DeliveryState_name = map[int32]string{
+		0: "DELIVERY_STATE_UNSPECIFIED",
+		1: "DELIVERY_STATE_QUEUED",
+		2: "DELIVERY_STATE_SENDING",
+		3: "DELIVERY_STATE_DEFERRED",
+		4: "DELIVERY_STATE_DELIVERED",
+		5: "DELIVERY_STATE_BOUNCED",
+		6: "DELIVERY_STATE_FAILED",
+	}
+	DeliveryState_value = map[string]int32{
+		"DELIVERY_STATE_UNSPECIFIED": 0,
+		"DELIVERY_STATE_QUEUED":      1,
+		"DELIVERY_STATE_SENDING":     2,
+		"DELIVERY_STATE_DEFERRED":    3,
+		"DELIVERY_STATE_DELIVERED":   4,
+		"DELIVERY_STATE_BOUNCED":     5,
+		"DELIVERY_STATE_FAILED":      6,
+	}
+)
+
+func (x DeliveryState) Enum() *DeliveryState {
+	p := new(DeliveryState)
+	*p = x
+	return p
+}
+
+func (x DeliveryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_email_proto_enumTypes[0].Descriptor()
+}
+
+func (DeliveryState) Type() protoreflect.EnumType {
+	return &file_proto_email_proto_enumTypes[0]
+}
+
+func (x DeliveryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryState.Descriptor instead.
+func (DeliveryState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{0}
+}
+
+// SuppressionReason 地址被抑制的原因
+type SuppressionReason int32
+
+const (
+	SuppressionReason_SUPPRESSION_REASON_UNSPECIFIED SuppressionReason = 0 // 未指定
+	SuppressionReason_SUPPRESSION_REASON_HARD_BOUNCE SuppressionReason = 1 // 硬退信，地址不存在或被永久拒收
+	SuppressionReason_SUPPRESSION_REASON_COMPLAINT   SuppressionReason = 2 // 收件人投诉为垃圾邮件
+	SuppressionReason_SUPPRESSION_REASON_UNSUBSCRIBE SuppressionReason = 3 // 收件人退订
+	SuppressionReason_SUPPRESSION_REASON_MANUAL      SuppressionReason = 4 // 手动添加
+)
+
+// Enum value maps for SuppressionReason.
+var (
+	SuppressionReason_name = map[int32]string{
+		0: "SUPPRESSION_REASON_UNSPECIFIED",
+		1: "SUPPRESSION_REASON_HARD_BOUNCE",
+		2: "SUPPRESSION_REASON_COMPLAINT",
+		3: "SUPPRESSION_REASON_UNSUBSCRIBE",
+		4: "SUPPRESSION_REASON_MANUAL",
+	}
+	SuppressionReason_value = map[string]int32{
+		"SUPPRESSION_REASON_UNSPECIFIED": 0,
+		"SUPPRESSION_REASON_HARD_BOUNCE": 1,
+		"SUPPRESSION_REASON_COMPLAINT":   2,
+		"SUPPRESSION_REASON_UNSUBSCRIBE": 3,
+		"SUPPRESSION_REASON_MANUAL":      4,
+	}
+)
+
+func (x SuppressionReason) Enum() *SuppressionReason {
+	p := new(SuppressionReason)
+	*p = x
+	return p
+}
+
+func (x SuppressionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SuppressionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_email_proto_enumTypes[1].Descriptor()
+}
+
+func (SuppressionReason) Type() protoreflect.EnumType {
+	return &file_proto_email_proto_enumTypes[1]
+}
+
+func (x SuppressionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SuppressionReason.Descriptor instead.
+func (SuppressionReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{1}
+}
+
+type Attachment_Disposition int32
+
+const (
+	Attachment_ATTACHMENT Attachment_Disposition = 0 // 普通附件
+	Attachment_INLINE     Attachment_Disposition = 1 // 内嵌资源，通过 Content-ID 在HTML正文中引用
+)
+
+// Enum value maps for Attachment_Disposition.
+var (
+	Attachment_Disposition_name = map[int32]string{
+		0: "ATTACHMENT",
+		1: "INLINE",
+	}
+	Attachment_Disposition_value = map[string]int32{
+		"ATTACHMENT": 0,
+		"INLINE":     1,
+	}
+)
+
+func (x Attachment_Disposition) Enum() *Attachment_Disposition {
+	p := new(Attachment_Disposition)
+	*p = x
+	return p
+}
+
+func (x Attachment_Disposition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Attachment_Disposition) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_email_proto_enumTypes[2].Descriptor()
+}
+
+func (Attachment_Disposition) Type() protoreflect.EnumType {
+	return &file_proto_email_proto_enumTypes[2]
+}
+
+func (x Attachment_Disposition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Attachment_Disposition.Descriptor instead.
+func (Attachment_Disposition) EnumDescriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type EmailConfig_Protocol int32
 
 const (
@@ -55,11 +220,11 @@ func (x EmailConfig_Protocol) String() string {
 }
 
 func (EmailConfig_Protocol) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_email_proto_enumTypes[0].Descriptor()
+	return file_proto_email_proto_enumTypes[3].Descriptor()
 }
 
 func (EmailConfig_Protocol) Type() protoreflect.EnumType {
-	return &file_proto_email_proto_enumTypes[0]
+	return &file_proto_email_proto_enumTypes[3]
 }
 
 func (x EmailConfig_Protocol) Number() protoreflect.EnumNumber {
@@ -71,6 +236,64 @@ func (EmailConfig_Protocol) EnumDescriptor() ([]byte, []int) {
 	return file_proto_email_proto_rawDescGZIP(), []int{2, 0}
 }
 
+type TemplateVariable_Type int32
+
+const (
+	TemplateVariable_STRING  TemplateVariable_Type = 0 // 字符串
+	TemplateVariable_NUMBER  TemplateVariable_Type = 1 // 数字
+	TemplateVariable_BOOLEAN TemplateVariable_Type = 2 // 布尔值
+	TemplateVariable_DATE    TemplateVariable_Type = 3 // 日期时间，使用 RFC 3339 格式的字符串传递
+	TemplateVariable_LIST    TemplateVariable_Type = 4 // 列表
+	TemplateVariable_OBJECT  TemplateVariable_Type = 5 // 对象
+)
+
+// Enum value maps for TemplateVariable_Type.
+var (
+	TemplateVariable_Type_name = map[int32]string{
+		0: "STRING",
+		1: "NUMBER",
+		2: "BOOLEAN",
+		3: "DATE",
+		4: "LIST",
+		5: "OBJECT",
+	}
+	TemplateVariable_Type_value = map[string]int32{
+		"STRING":  0,
+		"NUMBER":  1,
+		"BOOLEAN": 2,
+		"DATE":    3,
+		"LIST":    4,
+		"OBJECT":  5,
+	}
+)
+
+func (x TemplateVariable_Type) Enum() *TemplateVariable_Type {
+	p := new(TemplateVariable_Type)
+	*p = x
+	return p
+}
+
+func (x TemplateVariable_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TemplateVariable_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_email_proto_enumTypes[4].Descriptor()
+}
+
+func (TemplateVariable_Type) Type() protoreflect.EnumType {
+	return &file_proto_email_proto_enumTypes[4]
+}
+
+func (x TemplateVariable_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TemplateVariable_Type.Descriptor instead.
+func (TemplateVariable_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{36, 0}
+}
+
 type HealthCheckResponse_ServingStatus int32
 
 const (
@@ -107,11 +330,11 @@ func (x HealthCheckResponse_ServingStatus) String() string {
 }
 
 func (HealthCheckResponse_ServingStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_email_proto_enumTypes[1].Descriptor()
+	return file_proto_email_proto_enumTypes[5].Descriptor()
 }
 
 func (HealthCheckResponse_ServingStatus) Type() protoreflect.EnumType {
-	return &file_proto_email_proto_enumTypes[1]
+	return &file_proto_email_proto_enumTypes[5]
 }
 
 func (x HealthCheckResponse_ServingStatus) Number() protoreflect.EnumNumber {
@@ -120,16 +343,19 @@ func (x HealthCheckResponse_ServingStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HealthCheckResponse_ServingStatus.Descriptor instead.
 func (HealthCheckResponse_ServingStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_email_proto_rawDescGZIP(), []int{20, 0}
+	return file_proto_email_proto_rawDescGZIP(), []int{59, 0}
 }
 
 // Attachment 代表一个邮件附件
 type Attachment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                          // 附件文件名
-	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                            // 附件内容
-	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // 内容类型(MIME类型)
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                 // 附件大小(字节)
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                                          // 附件文件名
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                                            // 附件内容
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`                 // 内容类型(MIME类型)
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                                 // 附件大小(字节)
+	AttachmentId  string                 `protobuf:"bytes,5,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`              // 通过 UploadAttachment 上传的附件ID，设置后 content 可为空
+	Disposition   Attachment_Disposition `protobuf:"varint,6,opt,name=disposition,proto3,enum=email.Attachment_Disposition" json:"disposition,omitempty"` // 附件的展示方式
+	ContentId     string                 `protobuf:"bytes,7,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`                       // 内嵌资源的 Content-ID，HTML中以 cid:<content_id> 引用
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -192,17 +418,44 @@ func (x *Attachment) GetSize() int64 {
 	return 0
 }
 
+func (x *Attachment) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *Attachment) GetDisposition() Attachment_Disposition {
+	if x != nil {
+		return x.Disposition
+	}
+	return Attachment_ATTACHMENT
+}
+
+func (x *Attachment) GetContentId() string {
+	if x != nil {
+		return x.ContentId
+	}
+	return ""
+}
+
 // Email 代表一封邮件的结构
 type Email struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                          // 邮件标题
-	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                      // 邮件内容
-	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                            // 发件人地址
-	To            []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`                                // 收件人地址列表
-	Id            string                 `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`                                // 邮件唯一ID
-	SentAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`          // 邮件发送时间
-	Attachments   []*Attachment          `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`              // 邮件附件列表
-	EmailType     string                 `protobuf:"bytes,8,opt,name=email_type,json=emailType,proto3" json:"email_type,omitempty"` // 邮件类型: normal或test
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                                                                                // 邮件标题
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                                                                            // 邮件内容（兼容字段，优先使用 text_body 和 html_body）
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                                                                                  // 发件人地址
+	To            []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`                                                                                      // 收件人地址列表
+	Id            string                 `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`                                                                                      // 邮件唯一ID
+	SentAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`                                                                // 邮件发送时间
+	Attachments   []*Attachment          `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`                                                                    // 邮件附件列表
+	EmailType     string                 `protobuf:"bytes,8,opt,name=email_type,json=emailType,proto3" json:"email_type,omitempty"`                                                       // 邮件类型: normal或test
+	Cc            []string               `protobuf:"bytes,9,rep,name=cc,proto3" json:"cc,omitempty"`                                                                                      // 抄送地址列表
+	Bcc           []string               `protobuf:"bytes,10,rep,name=bcc,proto3" json:"bcc,omitempty"`                                                                                   // 密送地址列表
+	ReplyTo       string                 `protobuf:"bytes,11,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`                                                            // 回复地址
+	Headers       map[string]string      `protobuf:"bytes,12,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 自定义邮件头，如 List-Unsubscribe、X-Campaign
+	TextBody      string                 `protobuf:"bytes,13,opt,name=text_body,json=textBody,proto3" json:"text_body,omitempty"`                                                         // 纯文本正文，与 html_body 同时存在时组成 multipart/alternative
+	HtmlBody      string                 `protobuf:"bytes,14,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`                                                         // HTML正文
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -293,6 +546,48 @@ func (x *Email) GetEmailType() string {
 	return ""
 }
 
+func (x *Email) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *Email) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *Email) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *Email) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Email) GetTextBody() string {
+	if x != nil {
+		return x.TextBody
+	}
+	return ""
+}
+
+func (x *Email) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
 // EmailConfig 代表邮件服务器配置
 type EmailConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -942,12 +1237,19 @@ func (x *TestConfigResponse) GetMessage() string {
 
 // GetSentEmailsRequest 获取已发送邮件列表的请求
 type GetSentEmailsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                        // 游标，用于分页查询。为空表示从最新开始查询
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                         // 返回记录数限制，默认20，最大100
-	EmailType     string                 `protobuf:"bytes,3,opt,name=email_type,json=emailType,proto3" json:"email_type,omitempty"` // 邮件类型过滤，为空表示所有类型
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Cursor          string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                                               // 游标，用于分页查询。为空表示从最新开始查询
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                // 返回记录数限制，默认20，最大100
+	EmailType       string                 `protobuf:"bytes,3,opt,name=email_type,json=emailType,proto3" json:"email_type,omitempty"`                        // 邮件类型过滤，为空表示所有类型
+	Recipient       string                 `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`                                         // 收件人地址过滤，匹配 to、cc 和 bcc
+	Sender          string                 `protobuf:"bytes,5,opt,name=sender,proto3" json:"sender,omitempty"`                                               // 发件人地址过滤
+	SubjectContains string                 `protobuf:"bytes,6,opt,name=subject_contains,json=subjectContains,proto3" json:"subject_contains,omitempty"`      // 标题包含的子串，不区分大小写
+	ConfigId        string                 `protobuf:"bytes,7,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`                           // 发送时使用的邮件配置ID
+	SentAfter       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=sent_after,json=sentAfter,proto3" json:"sent_after,omitempty"`                        // 发送时间下限（包含）
+	SentBefore      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=sent_before,json=sentBefore,proto3" json:"sent_before,omitempty"`                     // 发送时间上限（不包含）
+	HasAttachments  *bool                  `protobuf:"varint,10,opt,name=has_attachments,json=hasAttachments,proto3,oneof" json:"has_attachments,omitempty"` // 是否带附件，未设置表示不限
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetSentEmailsRequest) Reset() {
@@ -1001,6 +1303,55 @@ func (x *GetSentEmailsRequest) GetEmailType() string {
 	return ""
 }
 
+func (x *GetSentEmailsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *GetSentEmailsRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *GetSentEmailsRequest) GetSubjectContains() string {
+	if x != nil {
+		return x.SubjectContains
+	}
+	return ""
+}
+
+func (x *GetSentEmailsRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *GetSentEmailsRequest) GetSentAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAfter
+	}
+	return nil
+}
+
+func (x *GetSentEmailsRequest) GetSentBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentBefore
+	}
+	return nil
+}
+
+func (x *GetSentEmailsRequest) GetHasAttachments() bool {
+	if x != nil && x.HasAttachments != nil {
+		return *x.HasAttachments
+	}
+	return false
+}
+
 // GetSentEmailsResponse 获取已发送邮件列表的响应
 type GetSentEmailsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1072,11 +1423,13 @@ func (x *GetSentEmailsResponse) GetTotal() int32 {
 
 // SendEmailRequest 发送单封邮件的请求
 type SendEmailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         *Email                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                       // 待发送的邮件信息
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"` // 使用的邮件配置ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          *Email                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                                         // 待发送的邮件信息
+	ConfigId       string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`                   // 使用的邮件配置ID
+	ScheduledAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`          // 计划发送时间，为空表示立即发送
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，服务端对相同的键只发送一次
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SendEmailRequest) Reset() {
@@ -1123,12 +1476,27 @@ func (x *SendEmailRequest) GetConfigId() string {
 	return ""
 }
 
+func (x *SendEmailRequest) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *SendEmailRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // SendEmailResponse 发送单封邮件的响应
 type SendEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`               // 是否发送成功
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                // 发送结果提示信息
-	EmailId       string                 `protobuf:"bytes,3,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 发送成功后的邮件ID
+	EmailId       string                 `protobuf:"bytes,3,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 发送成功后的邮件ID，计划邮件为可用于取消的ID
+	Scheduled     bool                   `protobuf:"varint,4,opt,name=scheduled,proto3" json:"scheduled,omitempty"`           // 邮件是否已进入计划发送队列
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1184,29 +1552,39 @@ func (x *SendEmailResponse) GetEmailId() string {
 	return ""
 }
 
-// SendEmailsRequest 批量发送邮件的请求
-type SendEmailsRequest struct {
+func (x *SendEmailResponse) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+// ScheduledEmail 尚未发送的计划邮件
+type ScheduledEmail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Emails        []*Email               `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`                     // 待发送的邮件列表
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"` // 使用的邮件配置ID
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                      // 计划邮件ID
+	Email         *Email                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`                                // 邮件信息
+	ConfigId      string                 `protobuf:"bytes,3,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`          // 使用的邮件配置ID
+	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"` // 计划发送时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // 创建时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendEmailsRequest) Reset() {
-	*x = SendEmailsRequest{}
+func (x *ScheduledEmail) Reset() {
+	*x = ScheduledEmail{}
 	mi := &file_proto_email_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendEmailsRequest) String() string {
+func (x *ScheduledEmail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendEmailsRequest) ProtoMessage() {}
+func (*ScheduledEmail) ProtoMessage() {}
 
-func (x *SendEmailsRequest) ProtoReflect() protoreflect.Message {
+func (x *ScheduledEmail) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_email_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1218,49 +1596,69 @@ func (x *SendEmailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendEmailsRequest.ProtoReflect.Descriptor instead.
-func (*SendEmailsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScheduledEmail.ProtoReflect.Descriptor instead.
+func (*ScheduledEmail) Descriptor() ([]byte, []int) {
 	return file_proto_email_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SendEmailsRequest) GetEmails() []*Email {
+func (x *ScheduledEmail) GetId() string {
 	if x != nil {
-		return x.Emails
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledEmail) GetEmail() *Email {
+	if x != nil {
+		return x.Email
 	}
 	return nil
 }
 
-func (x *SendEmailsRequest) GetConfigId() string {
+func (x *ScheduledEmail) GetConfigId() string {
 	if x != nil {
 		return x.ConfigId
 	}
 	return ""
 }
 
-// SendEmailsResponse 批量发送邮件的响应
-type SendEmailsResponse struct {
+func (x *ScheduledEmail) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *ScheduledEmail) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ListScheduledEmailsRequest 获取计划邮件列表的请求
+type ListScheduledEmailsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                  // 是否全部发送成功
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                   // 发送结果提示信息
-	EmailIds      []string               `protobuf:"bytes,3,rep,name=email_ids,json=emailIds,proto3" json:"email_ids,omitempty"` // 发送成功的邮件ID列表
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // 游标，用于分页查询。为空表示从最早的计划时间开始查询
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 返回记录数限制，默认20，最大100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendEmailsResponse) Reset() {
-	*x = SendEmailsResponse{}
+func (x *ListScheduledEmailsRequest) Reset() {
+	*x = ListScheduledEmailsRequest{}
 	mi := &file_proto_email_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendEmailsResponse) String() string {
+func (x *ListScheduledEmailsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendEmailsResponse) ProtoMessage() {}
+func (*ListScheduledEmailsRequest) ProtoMessage() {}
 
-func (x *SendEmailsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListScheduledEmailsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_email_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1272,32 +1670,2426 @@ func (x *SendEmailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendEmailsResponse.ProtoReflect.Descriptor instead.
-func (*SendEmailsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListScheduledEmailsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledEmailsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_email_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SendEmailsResponse) GetSuccess() bool {
+func (x *ListScheduledEmailsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListScheduledEmailsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListScheduledEmailsResponse 获取计划邮件列表的响应
+type ListScheduledEmailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emails        []*ScheduledEmail      `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`                           // 计划邮件列表，按计划发送时间升序排列
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页的游标，为空表示没有更多数据
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`         // 是否还有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledEmailsResponse) Reset() {
+	*x = ListScheduledEmailsResponse{}
+	mi := &file_proto_email_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledEmailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledEmailsResponse) ProtoMessage() {}
+
+func (x *ListScheduledEmailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledEmailsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledEmailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListScheduledEmailsResponse) GetEmails() []*ScheduledEmail {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *ListScheduledEmailsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListScheduledEmailsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+// CancelScheduledEmailRequest 取消计划邮件的请求
+type CancelScheduledEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 计划邮件ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledEmailRequest) Reset() {
+	*x = CancelScheduledEmailRequest{}
+	mi := &file_proto_email_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledEmailRequest) ProtoMessage() {}
+
+func (x *CancelScheduledEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledEmailRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelScheduledEmailRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// CancelScheduledEmailResponse 取消计划邮件的响应
+type CancelScheduledEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否取消成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 结果提示信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledEmailResponse) Reset() {
+	*x = CancelScheduledEmailResponse{}
+	mi := &file_proto_email_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledEmailResponse) ProtoMessage() {}
+
+func (x *CancelScheduledEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledEmailResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledEmailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelScheduledEmailResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelScheduledEmailResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SendEmailsRequest 批量发送邮件的请求
+type SendEmailsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Emails         []*Email               `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`                                       // 待发送的邮件列表
+	ConfigId       string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`                   // 使用的邮件配置ID
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，服务端对相同的键只发送一次
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SendEmailsRequest) Reset() {
+	*x = SendEmailsRequest{}
+	mi := &file_proto_email_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailsRequest) ProtoMessage() {}
+
+func (x *SendEmailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailsRequest.ProtoReflect.Descriptor instead.
+func (*SendEmailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SendEmailsRequest) GetEmails() []*Email {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *SendEmailsRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *SendEmailsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// SendEmailsResponse 批量发送邮件的响应
+type SendEmailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                  // 是否全部发送成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                   // 发送结果提示信息
+	EmailIds      []string               `protobuf:"bytes,3,rep,name=email_ids,json=emailIds,proto3" json:"email_ids,omitempty"` // 发送成功的邮件ID列表
+	Results       []*SendResult          `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`                   // 每封邮件的发送结果，与请求中的邮件一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailsResponse) Reset() {
+	*x = SendEmailsResponse{}
+	mi := &file_proto_email_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailsResponse) ProtoMessage() {}
+
+func (x *SendEmailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailsResponse.ProtoReflect.Descriptor instead.
+func (*SendEmailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SendEmailsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SendEmailsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SendEmailsResponse) GetEmailIds() []string {
+	if x != nil {
+		return x.EmailIds
+	}
+	return nil
+}
+
+func (x *SendEmailsResponse) GetResults() []*SendResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// GetEmailRequest 获取单封已发送邮件的请求
+type GetEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailId       string                 `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // SendEmailResponse 或 SendResult 中返回的邮件ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmailRequest) Reset() {
+	*x = GetEmailRequest{}
+	mi := &file_proto_email_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailRequest) ProtoMessage() {}
+
+func (x *GetEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetEmailRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+// GetEmailResponse 获取单封已发送邮件的响应
+type GetEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         *Email                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                           // 邮件信息
+	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`     // 发送时使用的邮件配置ID
+	State         DeliveryState          `protobuf:"varint,3,opt,name=state,proto3,enum=email.DeliveryState" json:"state,omitempty"` // 当前的整体投递状态
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmailResponse) Reset() {
+	*x = GetEmailResponse{}
+	mi := &file_proto_email_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailResponse) ProtoMessage() {}
+
+func (x *GetEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailResponse.ProtoReflect.Descriptor instead.
+func (*GetEmailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetEmailResponse) GetEmail() *Email {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *GetEmailResponse) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *GetEmailResponse) GetState() DeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return DeliveryState_DELIVERY_STATE_UNSPECIFIED
+}
+
+// GetDeliveryStatusRequest 获取投递状态的请求
+type GetDeliveryStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailId       string                 `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"` // 邮件ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryStatusRequest) Reset() {
+	*x = GetDeliveryStatusRequest{}
+	mi := &file_proto_email_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryStatusRequest) ProtoMessage() {}
+
+func (x *GetDeliveryStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetDeliveryStatusRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+// GetDeliveryStatusResponse 获取投递状态的响应
+type GetDeliveryStatusResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	EmailId       string                     `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`        // 邮件ID
+	State         DeliveryState              `protobuf:"varint,2,opt,name=state,proto3,enum=email.DeliveryState" json:"state,omitempty"` // 整体投递状态，取所有收件人中最需要关注的状态
+	Recipients    []*RecipientDeliveryStatus `protobuf:"bytes,3,rep,name=recipients,proto3" json:"recipients,omitempty"`                 // 每个收件人的投递状态
+	Events        []*DeliveryEvent           `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`                         // 投递事件时间线，按时间升序排列
+	UpdatedAt     *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`  // 状态最后更新时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryStatusResponse) Reset() {
+	*x = GetDeliveryStatusResponse{}
+	mi := &file_proto_email_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryStatusResponse) ProtoMessage() {}
+
+func (x *GetDeliveryStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetDeliveryStatusResponse) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *GetDeliveryStatusResponse) GetState() DeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return DeliveryState_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (x *GetDeliveryStatusResponse) GetRecipients() []*RecipientDeliveryStatus {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *GetDeliveryStatusResponse) GetEvents() []*DeliveryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetDeliveryStatusResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// RecipientDeliveryStatus 单个收件人的投递状态
+type RecipientDeliveryStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     string                 `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`                   // 收件人地址
+	State         DeliveryState          `protobuf:"varint,2,opt,name=state,proto3,enum=email.DeliveryState" json:"state,omitempty"` // 投递状态
+	Attempts      int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`                    // 已尝试投递的次数
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`  // 状态最后更新时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipientDeliveryStatus) Reset() {
+	*x = RecipientDeliveryStatus{}
+	mi := &file_proto_email_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipientDeliveryStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipientDeliveryStatus) ProtoMessage() {}
+
+func (x *RecipientDeliveryStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipientDeliveryStatus.ProtoReflect.Descriptor instead.
+func (*RecipientDeliveryStatus) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RecipientDeliveryStatus) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *RecipientDeliveryStatus) GetState() DeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return DeliveryState_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (x *RecipientDeliveryStatus) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *RecipientDeliveryStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DeliveryEvent 投递过程中的一次事件，通常对应一次SMTP响应
+type DeliveryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                           // 事件发生时间
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`                           // 相关的收件人地址，为空表示整封邮件
+	State         DeliveryState          `protobuf:"varint,3,opt,name=state,proto3,enum=email.DeliveryState" json:"state,omitempty"`         // 事件发生后的投递状态
+	SmtpCode      int32                  `protobuf:"varint,4,opt,name=smtp_code,json=smtpCode,proto3" json:"smtp_code,omitempty"`            // SMTP响应码，如 250、421、550
+	EnhancedCode  string                 `protobuf:"bytes,5,opt,name=enhanced_code,json=enhancedCode,proto3" json:"enhanced_code,omitempty"` // 增强状态码(RFC 3463)，如 5.1.1
+	SmtpResponse  string                 `protobuf:"bytes,6,opt,name=smtp_response,json=smtpResponse,proto3" json:"smtp_response,omitempty"` // SMTP响应文本
+	RemoteMta     string                 `protobuf:"bytes,7,opt,name=remote_mta,json=remoteMta,proto3" json:"remote_mta,omitempty"`          // 收件服务器主机名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryEvent) Reset() {
+	*x = DeliveryEvent{}
+	mi := &file_proto_email_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryEvent) ProtoMessage() {}
+
+func (x *DeliveryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryEvent.ProtoReflect.Descriptor instead.
+func (*DeliveryEvent) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeliveryEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *DeliveryEvent) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetState() DeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return DeliveryState_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (x *DeliveryEvent) GetSmtpCode() int32 {
+	if x != nil {
+		return x.SmtpCode
+	}
+	return 0
+}
+
+func (x *DeliveryEvent) GetEnhancedCode() string {
+	if x != nil {
+		return x.EnhancedCode
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetSmtpResponse() string {
+	if x != nil {
+		return x.SmtpResponse
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetRemoteMta() string {
+	if x != nil {
+		return x.RemoteMta
+	}
+	return ""
+}
+
+// SendEmailsStreamRequest 流式发送中的一封邮件
+type SendEmailsStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         *Email                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                       // 待发送的邮件
+	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"` // 使用的邮件配置ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailsStreamRequest) Reset() {
+	*x = SendEmailsStreamRequest{}
+	mi := &file_proto_email_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailsStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailsStreamRequest) ProtoMessage() {}
+
+func (x *SendEmailsStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailsStreamRequest.ProtoReflect.Descriptor instead.
+func (*SendEmailsStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SendEmailsStreamRequest) GetEmail() *Email {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *SendEmailsStreamRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+// SendEmailEvent 流式发送中单封邮件的发送事件
+type SendEmailEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *SendResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`       // 发送结果，index 为邮件在流中的序号
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 事件发生时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailEvent) Reset() {
+	*x = SendEmailEvent{}
+	mi := &file_proto_email_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailEvent) ProtoMessage() {}
+
+func (x *SendEmailEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailEvent.ProtoReflect.Descriptor instead.
+func (*SendEmailEvent) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SendEmailEvent) GetResult() *SendResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *SendEmailEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// SendResult 批量发送中单封邮件的发送结果
+type SendResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                  // 邮件在请求中的下标
+	EmailId       string                 `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`                // 发送成功时的邮件ID
+	Code          int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`                                    // 状态码，取值同 gRPC 状态码，0 表示成功
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // 发送失败时的错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendResult) Reset() {
+	*x = SendResult{}
+	mi := &file_proto_email_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendResult) ProtoMessage() {}
+
+func (x *SendResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendResult.ProtoReflect.Descriptor instead.
+func (*SendResult) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SendResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SendResult) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *SendResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *SendResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// AttachmentMetadata 上传附件时的元数据
+type AttachmentMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                          // 附件文件名
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // 内容类型(MIME类型)
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                                 // 附件大小(字节)，未知时为0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMetadata) Reset() {
+	*x = AttachmentMetadata{}
+	mi := &file_proto_email_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMetadata) ProtoMessage() {}
+
+func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AttachmentMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// UploadAttachmentRequest 上传附件的流式请求，第一条消息必须是元数据，后续消息为数据块
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_proto_email_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetMetadata() *AttachmentMetadata {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *AttachmentMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"` // 附件元数据
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // 附件数据块
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+// UploadAttachmentResponse 上传附件的响应
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                              // 是否上传成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                               // 上传结果提示信息
+	AttachmentId  string                 `protobuf:"bytes,3,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"` // 附件ID，发送邮件时通过 Attachment.attachment_id 引用
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                    // 服务端实际接收的字节数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_proto_email_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UploadAttachmentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UploadAttachmentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UploadAttachmentResponse) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *UploadAttachmentResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// TemplateVariable 描述模板中使用的变量
+type TemplateVariable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // 变量名称
+	Type          TemplateVariable_Type  `protobuf:"varint,2,opt,name=type,proto3,enum=email.TemplateVariable_Type" json:"type,omitempty"`   // 变量类型
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`                            // 是否必填
+	DefaultValue  *structpb.Value        `protobuf:"bytes,4,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"` // 未提供时使用的默认值
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                       // 变量说明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateVariable) Reset() {
+	*x = TemplateVariable{}
+	mi := &file_proto_email_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateVariable) ProtoMessage() {}
+
+func (x *TemplateVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateVariable.ProtoReflect.Descriptor instead.
+func (*TemplateVariable) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TemplateVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateVariable) GetType() TemplateVariable_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TemplateVariable_STRING
+}
+
+func (x *TemplateVariable) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *TemplateVariable) GetDefaultValue() *structpb.Value {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return nil
+}
+
+func (x *TemplateVariable) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// EmailTemplate 代表一个邮件模板
+type EmailTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                // 模板唯一ID
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                            // 模板名称
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`              // 模板描述
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`                      // 邮件标题模板
+	HtmlBody      string                 `protobuf:"bytes,5,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`    // HTML正文模板
+	TextBody      string                 `protobuf:"bytes,6,opt,name=text_body,json=textBody,proto3" json:"text_body,omitempty"`    // 纯文本正文模板
+	Variables     []*TemplateVariable    `protobuf:"bytes,7,rep,name=variables,proto3" json:"variables,omitempty"`                  // 模板变量定义
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 模板创建时间
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // 模板更新时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailTemplate) Reset() {
+	*x = EmailTemplate{}
+	mi := &file_proto_email_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailTemplate) ProtoMessage() {}
+
+func (x *EmailTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailTemplate.ProtoReflect.Descriptor instead.
+func (*EmailTemplate) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *EmailTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetTextBody() string {
+	if x != nil {
+		return x.TextBody
+	}
+	return ""
+}
+
+func (x *EmailTemplate) GetVariables() []*TemplateVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *EmailTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *EmailTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateTemplateRequest 创建邮件模板的请求
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *EmailTemplate         `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"` // 待创建的模板
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_proto_email_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateTemplateRequest) GetTemplate() *EmailTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// GetTemplateRequest 获取邮件模板的请求
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 待查询的模板ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_proto_email_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// UpdateTemplateRequest 更新邮件模板的请求
+type UpdateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *EmailTemplate         `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"` // 待更新的模板
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_proto_email_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateTemplateRequest) GetTemplate() *EmailTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// DeleteTemplateRequest 删除邮件模板的请求
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 待删除的模板ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_proto_email_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteTemplateResponse 删除邮件模板的响应
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否删除成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 操作结果提示信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_proto_email_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteTemplateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// TemplateResponse 邮件模板操作的通用响应
+type TemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // 操作是否成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`   // 操作结果提示信息
+	Template      *EmailTemplate         `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"` // 相关的模板信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateResponse) Reset() {
+	*x = TemplateResponse{}
+	mi := &file_proto_email_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateResponse) ProtoMessage() {}
+
+func (x *TemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateResponse.ProtoReflect.Descriptor instead.
+func (*TemplateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TemplateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TemplateResponse) GetTemplate() *EmailTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// ListTemplatesRequest 获取邮件模板列表的请求
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // 游标，用于分页查询。为空表示从最新开始查询
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 返回记录数限制
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_proto_email_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListTemplatesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTemplatesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListTemplatesResponse 获取邮件模板列表的响应
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*EmailTemplate       `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`                     // 模板列表
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页的游标，为空表示没有更多数据
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`         // 是否还有更多数据
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // 总记录数（可选）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_proto_email_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*EmailTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+func (x *ListTemplatesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListTemplatesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListTemplatesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// RenderTemplateRequest 渲染模板预览的请求
+type RenderTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"` // 模板ID
+	Variables     *structpb.Struct       `protobuf:"bytes,2,opt,name=variables,proto3" json:"variables,omitempty"`                     // 模板变量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTemplateRequest) Reset() {
+	*x = RenderTemplateRequest{}
+	mi := &file_proto_email_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTemplateRequest) ProtoMessage() {}
+
+func (x *RenderTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTemplateRequest.ProtoReflect.Descriptor instead.
+func (*RenderTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RenderTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *RenderTemplateRequest) GetVariables() *structpb.Struct {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+// RenderTemplateResponse 渲染模板预览的响应
+type RenderTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                  // 是否渲染成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                   // 渲染结果提示信息，如缺少必填变量
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`                   // 渲染后的邮件标题
+	HtmlBody      string                 `protobuf:"bytes,4,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"` // 渲染后的HTML正文
+	TextBody      string                 `protobuf:"bytes,5,opt,name=text_body,json=textBody,proto3" json:"text_body,omitempty"` // 渲染后的纯文本正文
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTemplateResponse) Reset() {
+	*x = RenderTemplateResponse{}
+	mi := &file_proto_email_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTemplateResponse) ProtoMessage() {}
+
+func (x *RenderTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTemplateResponse.ProtoReflect.Descriptor instead.
+func (*RenderTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RenderTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RenderTemplateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetTextBody() string {
+	if x != nil {
+		return x.TextBody
+	}
+	return ""
+}
+
+// SendTemplatedEmailRequest 使用模板发送邮件的请求
+type SendTemplatedEmailRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId     string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`             // 模板ID
+	Variables      *structpb.Struct       `protobuf:"bytes,2,opt,name=variables,proto3" json:"variables,omitempty"`                                 // 模板变量
+	From           string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                                           // 发件人地址，为空时使用配置中的默认地址
+	To             []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`                                               // 收件人地址列表
+	ConfigId       string                 `protobuf:"bytes,5,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`                   // 使用的邮件配置ID
+	EmailType      string                 `protobuf:"bytes,6,opt,name=email_type,json=emailType,proto3" json:"email_type,omitempty"`                // 邮件类型: normal或test
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`                                               // 抄送地址列表
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`                                             // 密送地址列表
+	IdempotencyKey string                 `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，服务端对相同的键只发送一次
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SendTemplatedEmailRequest) Reset() {
+	*x = SendTemplatedEmailRequest{}
+	mi := &file_proto_email_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTemplatedEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTemplatedEmailRequest) ProtoMessage() {}
+
+func (x *SendTemplatedEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTemplatedEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendTemplatedEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SendTemplatedEmailRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *SendTemplatedEmailRequest) GetVariables() *structpb.Struct {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SendTemplatedEmailRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SendTemplatedEmailRequest) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *SendTemplatedEmailRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *SendTemplatedEmailRequest) GetEmailType() string {
+	if x != nil {
+		return x.EmailType
+	}
+	return ""
+}
+
+func (x *SendTemplatedEmailRequest) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *SendTemplatedEmailRequest) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *SendTemplatedEmailRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Suppression 抑制列表中的一个地址
+type Suppression struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`                             // 邮件地址
+	Reason        SuppressionReason      `protobuf:"varint,2,opt,name=reason,proto3,enum=email.SuppressionReason" json:"reason,omitempty"` // 抑制原因
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                     // 补充说明，如退信的SMTP响应
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`        // 加入时间
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`        // 过期时间，为空表示永久抑制
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suppression) Reset() {
+	*x = Suppression{}
+	mi := &file_proto_email_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suppression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suppression) ProtoMessage() {}
+
+func (x *Suppression) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suppression.ProtoReflect.Descriptor instead.
+func (*Suppression) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Suppression) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Suppression) GetReason() SuppressionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return SuppressionReason_SUPPRESSION_REASON_UNSPECIFIED
+}
+
+func (x *Suppression) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Suppression) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Suppression) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// AddSuppressionRequest 将地址加入抑制列表的请求
+type AddSuppressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppression   *Suppression           `protobuf:"bytes,1,opt,name=suppression,proto3" json:"suppression,omitempty"` // 待加入的地址，created_at 由服务端设置
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSuppressionRequest) Reset() {
+	*x = AddSuppressionRequest{}
+	mi := &file_proto_email_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSuppressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSuppressionRequest) ProtoMessage() {}
+
+func (x *AddSuppressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSuppressionRequest.ProtoReflect.Descriptor instead.
+func (*AddSuppressionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AddSuppressionRequest) GetSuppression() *Suppression {
+	if x != nil {
+		return x.Suppression
+	}
+	return nil
+}
+
+// SuppressionResponse 抑制列表操作的通用响应
+type SuppressionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`        // 操作是否成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`         // 操作结果提示信息
+	Suppression   *Suppression           `protobuf:"bytes,3,opt,name=suppression,proto3" json:"suppression,omitempty"` // 相关的抑制记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuppressionResponse) Reset() {
+	*x = SuppressionResponse{}
+	mi := &file_proto_email_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuppressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuppressionResponse) ProtoMessage() {}
+
+func (x *SuppressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuppressionResponse.ProtoReflect.Descriptor instead.
+func (*SuppressionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SuppressionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SuppressionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SuppressionResponse) GetSuppression() *Suppression {
+	if x != nil {
+		return x.Suppression
+	}
+	return nil
+}
+
+// RemoveSuppressionRequest 将地址移出抑制列表的请求
+type RemoveSuppressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // 邮件地址
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSuppressionRequest) Reset() {
+	*x = RemoveSuppressionRequest{}
+	mi := &file_proto_email_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSuppressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSuppressionRequest) ProtoMessage() {}
+
+func (x *RemoveSuppressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSuppressionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSuppressionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RemoveSuppressionRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// RemoveSuppressionResponse 将地址移出抑制列表的响应
+type RemoveSuppressionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否移出成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 操作结果提示信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSuppressionResponse) Reset() {
+	*x = RemoveSuppressionResponse{}
+	mi := &file_proto_email_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSuppressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSuppressionResponse) ProtoMessage() {}
+
+func (x *RemoveSuppressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSuppressionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveSuppressionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RemoveSuppressionResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *SendEmailsResponse) GetMessage() string {
+func (x *RemoveSuppressionResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *SendEmailsResponse) GetEmailIds() []string {
+// CheckSuppressionRequest 检查地址是否被抑制的请求
+type CheckSuppressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"` // 待检查的邮件地址，最多100个
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckSuppressionRequest) Reset() {
+	*x = CheckSuppressionRequest{}
+	mi := &file_proto_email_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckSuppressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckSuppressionRequest) ProtoMessage() {}
+
+func (x *CheckSuppressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[54]
 	if x != nil {
-		return x.EmailIds
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckSuppressionRequest.ProtoReflect.Descriptor instead.
+func (*CheckSuppressionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CheckSuppressionRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// CheckSuppressionResponse 检查地址是否被抑制的响应
+type CheckSuppressionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppressions  []*Suppression         `protobuf:"bytes,1,rep,name=suppressions,proto3" json:"suppressions,omitempty"` // 被抑制的地址，未被抑制的地址不会出现在结果中
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckSuppressionResponse) Reset() {
+	*x = CheckSuppressionResponse{}
+	mi := &file_proto_email_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckSuppressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckSuppressionResponse) ProtoMessage() {}
+
+func (x *CheckSuppressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckSuppressionResponse.ProtoReflect.Descriptor instead.
+func (*CheckSuppressionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CheckSuppressionResponse) GetSuppressions() []*Suppression {
+	if x != nil {
+		return x.Suppressions
+	}
+	return nil
+}
+
+// ListSuppressionsRequest 获取抑制列表的请求
+type ListSuppressionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                               // 游标，用于分页查询。为空表示从最新开始查询
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                // 返回记录数限制，默认20，最大1000
+	Reason        SuppressionReason      `protobuf:"varint,3,opt,name=reason,proto3,enum=email.SuppressionReason" json:"reason,omitempty"` // 按抑制原因过滤，未指定时返回所有原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppressionsRequest) Reset() {
+	*x = ListSuppressionsRequest{}
+	mi := &file_proto_email_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppressionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppressionsRequest) ProtoMessage() {}
+
+func (x *ListSuppressionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppressionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSuppressionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListSuppressionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListSuppressionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSuppressionsRequest) GetReason() SuppressionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return SuppressionReason_SUPPRESSION_REASON_UNSPECIFIED
+}
+
+// ListSuppressionsResponse 获取抑制列表的响应
+type ListSuppressionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppressions  []*Suppression         `protobuf:"bytes,1,rep,name=suppressions,proto3" json:"suppressions,omitempty"`               // 抑制记录列表，不包含已过期的记录
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页的游标，为空表示没有更多数据
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`         // 是否还有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppressionsResponse) Reset() {
+	*x = ListSuppressionsResponse{}
+	mi := &file_proto_email_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppressionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppressionsResponse) ProtoMessage() {}
+
+func (x *ListSuppressionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_email_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppressionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSuppressionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_email_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListSuppressionsResponse) GetSuppressions() []*Suppression {
+	if x != nil {
+		return x.Suppressions
 	}
 	return nil
 }
 
+func (x *ListSuppressionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListSuppressionsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
 // HealthCheckRequest 健康检查请求
 type HealthCheckRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1309,7 +4101,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_proto_email_proto_msgTypes[19]
+	mi := &file_proto_email_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1321,7 +4113,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_email_proto_msgTypes[19]
+	mi := &file_proto_email_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1334,7 +4126,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_proto_email_proto_rawDescGZIP(), []int{19}
+	return file_proto_email_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *HealthCheckRequest) GetService() string {
@@ -1355,7 +4147,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_proto_email_proto_msgTypes[20]
+	mi := &file_proto_email_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1367,7 +4159,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_email_proto_msgTypes[20]
+	mi := &file_proto_email_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,7 +4172,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_proto_email_proto_rawDescGZIP(), []int{20}
+	return file_proto_email_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *HealthCheckResponse) GetStatus() HealthCheckResponse_ServingStatus {
@@ -1401,13 +4193,22 @@ var File_proto_email_proto protoreflect.FileDescriptor
 
 const file_proto_email_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/email.proto\x12\x05email\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
+	"\x11proto/email.proto\x12\x05email\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x02\n" +
 	"\n" +
 	"Attachment\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\fR\acontent\x12!\n" +
 	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\"\xf4\x01\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12#\n" +
+	"\rattachment_id\x18\x05 \x01(\tR\fattachmentId\x12?\n" +
+	"\vdisposition\x18\x06 \x01(\x0e2\x1d.email.Attachment.DispositionR\vdisposition\x12\x1d\n" +
+	"\n" +
+	"content_id\x18\a \x01(\tR\tcontentId\")\n" +
+	"\vDisposition\x12\x0e\n" +
+	"\n" +
+	"ATTACHMENT\x10\x00\x12\n" +
+	"\n" +
+	"\x06INLINE\x10\x01\"\xdc\x03\n" +
 	"\x05Email\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\fR\acontent\x12\x12\n" +
@@ -1417,7 +4218,17 @@ const file_proto_email_proto_rawDesc = "" +
 	"\asent_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\x123\n" +
 	"\vattachments\x18\a \x03(\v2\x11.email.AttachmentR\vattachments\x12\x1d\n" +
 	"\n" +
-	"email_type\x18\b \x01(\tR\temailType\"\xc3\x03\n" +
+	"email_type\x18\b \x01(\tR\temailType\x12\x0e\n" +
+	"\x02cc\x18\t \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\n" +
+	" \x03(\tR\x03bcc\x12\x19\n" +
+	"\breply_to\x18\v \x01(\tR\areplyTo\x123\n" +
+	"\aheaders\x18\f \x03(\v2\x19.email.Email.HeadersEntryR\aheaders\x12\x1b\n" +
+	"\ttext_body\x18\r \x01(\tR\btextBody\x12\x1b\n" +
+	"\thtml_body\x18\x0e \x01(\tR\bhtmlBody\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x03\n" +
 	"\vEmailConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
 	"\bprotocol\x18\x02 \x01(\x0e2\x1b.email.EmailConfig.ProtocolR\bprotocol\x12\x16\n" +
@@ -1466,32 +4277,231 @@ const file_proto_email_proto_rawDesc = "" +
 	"\x06config\x18\x01 \x01(\v2\x12.email.EmailConfigR\x06config\"H\n" +
 	"\x12TestConfigResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"c\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9b\x03\n" +
 	"\x14GetSentEmailsRequest\x12\x16\n" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
 	"\n" +
-	"email_type\x18\x03 \x01(\tR\temailType\"\x8f\x01\n" +
+	"email_type\x18\x03 \x01(\tR\temailType\x12\x1c\n" +
+	"\trecipient\x18\x04 \x01(\tR\trecipient\x12\x16\n" +
+	"\x06sender\x18\x05 \x01(\tR\x06sender\x12)\n" +
+	"\x10subject_contains\x18\x06 \x01(\tR\x0fsubjectContains\x12\x1b\n" +
+	"\tconfig_id\x18\a \x01(\tR\bconfigId\x129\n" +
+	"\n" +
+	"sent_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tsentAfter\x12;\n" +
+	"\vsent_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"sentBefore\x12,\n" +
+	"\x0fhas_attachments\x18\n" +
+	" \x01(\bH\x00R\x0ehasAttachments\x88\x01\x01B\x12\n" +
+	"\x10_has_attachments\"\x8f\x01\n" +
 	"\x15GetSentEmailsResponse\x12$\n" +
 	"\x06emails\x18\x01 \x03(\v2\f.email.EmailR\x06emails\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
 	"nextCursor\x12\x19\n" +
 	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x05R\x05total\"S\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xbb\x01\n" +
 	"\x10SendEmailRequest\x12\"\n" +
 	"\x05email\x18\x01 \x01(\v2\f.email.EmailR\x05email\x12\x1b\n" +
-	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\"b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12=\n" +
+	"\fscheduled_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\x80\x01\n" +
 	"\x11SendEmailResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
-	"\bemail_id\x18\x03 \x01(\tR\aemailId\"V\n" +
+	"\bemail_id\x18\x03 \x01(\tR\aemailId\x12\x1c\n" +
+	"\tscheduled\x18\x04 \x01(\bR\tscheduled\"\xdb\x01\n" +
+	"\x0eScheduledEmail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\x05email\x18\x02 \x01(\v2\f.email.EmailR\x05email\x12\x1b\n" +
+	"\tconfig_id\x18\x03 \x01(\tR\bconfigId\x12=\n" +
+	"\fscheduled_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"J\n" +
+	"\x1aListScheduledEmailsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x88\x01\n" +
+	"\x1bListScheduledEmailsResponse\x12-\n" +
+	"\x06emails\x18\x01 \x03(\v2\x15.email.ScheduledEmailR\x06emails\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"-\n" +
+	"\x1bCancelScheduledEmailRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
+	"\x1cCancelScheduledEmailResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x7f\n" +
 	"\x11SendEmailsRequest\x12$\n" +
 	"\x06emails\x18\x01 \x03(\v2\f.email.EmailR\x06emails\x12\x1b\n" +
-	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\"e\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\x92\x01\n" +
 	"\x12SendEmailsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
-	"\temail_ids\x18\x03 \x03(\tR\bemailIds\".\n" +
+	"\temail_ids\x18\x03 \x03(\tR\bemailIds\x12+\n" +
+	"\aresults\x18\x04 \x03(\v2\x11.email.SendResultR\aresults\",\n" +
+	"\x0fGetEmailRequest\x12\x19\n" +
+	"\bemail_id\x18\x01 \x01(\tR\aemailId\"\x7f\n" +
+	"\x10GetEmailResponse\x12\"\n" +
+	"\x05email\x18\x01 \x01(\v2\f.email.EmailR\x05email\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12*\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x14.email.DeliveryStateR\x05state\"5\n" +
+	"\x18GetDeliveryStatusRequest\x12\x19\n" +
+	"\bemail_id\x18\x01 \x01(\tR\aemailId\"\x8b\x02\n" +
+	"\x19GetDeliveryStatusResponse\x12\x19\n" +
+	"\bemail_id\x18\x01 \x01(\tR\aemailId\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.email.DeliveryStateR\x05state\x12>\n" +
+	"\n" +
+	"recipients\x18\x03 \x03(\v2\x1e.email.RecipientDeliveryStatusR\n" +
+	"recipients\x12,\n" +
+	"\x06events\x18\x04 \x03(\v2\x14.email.DeliveryEventR\x06events\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xba\x01\n" +
+	"\x17RecipientDeliveryStatus\x12\x1c\n" +
+	"\trecipient\x18\x01 \x01(\tR\trecipient\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.email.DeliveryStateR\x05state\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x05R\battempts\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x99\x02\n" +
+	"\rDeliveryEvent\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12*\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x14.email.DeliveryStateR\x05state\x12\x1b\n" +
+	"\tsmtp_code\x18\x04 \x01(\x05R\bsmtpCode\x12#\n" +
+	"\renhanced_code\x18\x05 \x01(\tR\fenhancedCode\x12#\n" +
+	"\rsmtp_response\x18\x06 \x01(\tR\fsmtpResponse\x12\x1d\n" +
+	"\n" +
+	"remote_mta\x18\a \x01(\tR\tremoteMta\"Z\n" +
+	"\x17SendEmailsStreamRequest\x12\"\n" +
+	"\x05email\x18\x01 \x01(\v2\f.email.EmailR\x05email\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\"u\n" +
+	"\x0eSendEmailEvent\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.email.SendResultR\x06result\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"v\n" +
+	"\n" +
+	"SendResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x19\n" +
+	"\bemail_id\x18\x02 \x01(\tR\aemailId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"g\n" +
+	"\x12AttachmentMetadata\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"r\n" +
+	"\x17UploadAttachmentRequest\x127\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.email.AttachmentMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x87\x01\n" +
+	"\x18UploadAttachmentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rattachment_id\x18\x03 \x01(\tR\fattachmentId\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"\xa0\x02\n" +
+	"\x10TemplateVariable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1c.email.TemplateVariable.TypeR\x04type\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12;\n" +
+	"\rdefault_value\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\fdefaultValue\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"K\n" +
+	"\x04Type\x12\n" +
+	"\n" +
+	"\x06STRING\x10\x00\x12\n" +
+	"\n" +
+	"\x06NUMBER\x10\x01\x12\v\n" +
+	"\aBOOLEAN\x10\x02\x12\b\n" +
+	"\x04DATE\x10\x03\x12\b\n" +
+	"\x04LIST\x10\x04\x12\n" +
+	"\n" +
+	"\x06OBJECT\x10\x05\"\xd6\x02\n" +
+	"\rEmailTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x1b\n" +
+	"\thtml_body\x18\x05 \x01(\tR\bhtmlBody\x12\x1b\n" +
+	"\ttext_body\x18\x06 \x01(\tR\btextBody\x125\n" +
+	"\tvariables\x18\a \x03(\v2\x17.email.TemplateVariableR\tvariables\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"I\n" +
+	"\x15CreateTemplateRequest\x120\n" +
+	"\btemplate\x18\x01 \x01(\v2\x14.email.EmailTemplateR\btemplate\"$\n" +
+	"\x12GetTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x15UpdateTemplateRequest\x120\n" +
+	"\btemplate\x18\x01 \x01(\v2\x14.email.EmailTemplateR\btemplate\"'\n" +
+	"\x15DeleteTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x16DeleteTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"x\n" +
+	"\x10TemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\btemplate\x18\x03 \x01(\v2\x14.email.EmailTemplateR\btemplate\"D\n" +
+	"\x14ListTemplatesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x9d\x01\n" +
+	"\x15ListTemplatesResponse\x122\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x14.email.EmailTemplateR\ttemplates\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"o\n" +
+	"\x15RenderTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x125\n" +
+	"\tvariables\x18\x02 \x01(\v2\x17.google.protobuf.StructR\tvariables\"\xa0\x01\n" +
+	"\x16RenderTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1b\n" +
+	"\thtml_body\x18\x04 \x01(\tR\bhtmlBody\x12\x1b\n" +
+	"\ttext_body\x18\x05 \x01(\tR\btextBody\"\x9e\x02\n" +
+	"\x19SendTemplatedEmailRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x125\n" +
+	"\tvariables\x18\x02 \x01(\v2\x17.google.protobuf.StructR\tvariables\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x03(\tR\x02to\x12\x1b\n" +
+	"\tconfig_id\x18\x05 \x01(\tR\bconfigId\x12\x1d\n" +
+	"\n" +
+	"email_type\x18\x06 \x01(\tR\temailType\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12'\n" +
+	"\x0fidempotency_key\x18\t \x01(\tR\x0eidempotencyKey\"\xf1\x01\n" +
+	"\vSuppression\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.email.SuppressionReasonR\x06reason\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"M\n" +
+	"\x15AddSuppressionRequest\x124\n" +
+	"\vsuppression\x18\x01 \x01(\v2\x12.email.SuppressionR\vsuppression\"\x7f\n" +
+	"\x13SuppressionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\vsuppression\x18\x03 \x01(\v2\x12.email.SuppressionR\vsuppression\"4\n" +
+	"\x18RemoveSuppressionRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"O\n" +
+	"\x19RemoveSuppressionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"7\n" +
+	"\x17CheckSuppressionRequest\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"R\n" +
+	"\x18CheckSuppressionResponse\x126\n" +
+	"\fsuppressions\x18\x01 \x03(\v2\x12.email.SuppressionR\fsuppressions\"y\n" +
+	"\x17ListSuppressionsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x120\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x18.email.SuppressionReasonR\x06reason\"\x8e\x01\n" +
+	"\x18ListSuppressionsResponse\x126\n" +
+	"\fsuppressions\x18\x01 \x03(\v2\x12.email.SuppressionR\fsuppressions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\".\n" +
 	"\x12HealthCheckRequest\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\"\xc4\x01\n" +
 	"\x13HealthCheckResponse\x12@\n" +
@@ -1501,12 +4511,32 @@ const file_proto_email_proto_rawDesc = "" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aSERVING\x10\x01\x12\x0f\n" +
 	"\vNOT_SERVING\x10\x02\x12\x15\n" +
-	"\x11SERVING_UNHEALTHY\x10\x032\xdd\x01\n" +
+	"\x11SERVING_UNHEALTHY\x10\x03*\xd8\x01\n" +
+	"\rDeliveryState\x12\x1e\n" +
+	"\x1aDELIVERY_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15DELIVERY_STATE_QUEUED\x10\x01\x12\x1a\n" +
+	"\x16DELIVERY_STATE_SENDING\x10\x02\x12\x1b\n" +
+	"\x17DELIVERY_STATE_DEFERRED\x10\x03\x12\x1c\n" +
+	"\x18DELIVERY_STATE_DELIVERED\x10\x04\x12\x1a\n" +
+	"\x16DELIVERY_STATE_BOUNCED\x10\x05\x12\x19\n" +
+	"\x15DELIVERY_STATE_FAILED\x10\x06*\xc0\x01\n" +
+	"\x11SuppressionReason\x12\"\n" +
+	"\x1eSUPPRESSION_REASON_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eSUPPRESSION_REASON_HARD_BOUNCE\x10\x01\x12 \n" +
+	"\x1cSUPPRESSION_REASON_COMPLAINT\x10\x02\x12\"\n" +
+	"\x1eSUPPRESSION_REASON_UNSUBSCRIBE\x10\x03\x12\x1d\n" +
+	"\x19SUPPRESSION_REASON_MANUAL\x10\x042\xd7\x05\n" +
 	"\fEmailService\x12J\n" +
 	"\rGetSentEmails\x12\x1b.email.GetSentEmailsRequest\x1a\x1c.email.GetSentEmailsResponse\x12>\n" +
 	"\tSendEmail\x12\x17.email.SendEmailRequest\x1a\x18.email.SendEmailResponse\x12A\n" +
 	"\n" +
-	"SendEmails\x12\x18.email.SendEmailsRequest\x1a\x19.email.SendEmailsResponse2\xa9\x03\n" +
+	"SendEmails\x12\x18.email.SendEmailsRequest\x1a\x19.email.SendEmailsResponse\x12U\n" +
+	"\x10UploadAttachment\x12\x1e.email.UploadAttachmentRequest\x1a\x1f.email.UploadAttachmentResponse(\x01\x12M\n" +
+	"\x10SendEmailsStream\x12\x1e.email.SendEmailsStreamRequest\x1a\x15.email.SendEmailEvent(\x010\x01\x12\\\n" +
+	"\x13ListScheduledEmails\x12!.email.ListScheduledEmailsRequest\x1a\".email.ListScheduledEmailsResponse\x12_\n" +
+	"\x14CancelScheduledEmail\x12\".email.CancelScheduledEmailRequest\x1a#.email.CancelScheduledEmailResponse\x12;\n" +
+	"\bGetEmail\x12\x16.email.GetEmailRequest\x1a\x17.email.GetEmailResponse\x12V\n" +
+	"\x11GetDeliveryStatus\x12\x1f.email.GetDeliveryStatusRequest\x1a .email.GetDeliveryStatusResponse2\xa9\x03\n" +
 	"\x12EmailConfigService\x12A\n" +
 	"\fCreateConfig\x12\x1a.email.CreateConfigRequest\x1a\x15.email.ConfigResponse\x12;\n" +
 	"\tGetConfig\x12\x17.email.GetConfigRequest\x1a\x15.email.ConfigResponse\x12A\n" +
@@ -1514,7 +4544,20 @@ const file_proto_email_proto_rawDesc = "" +
 	"\fDeleteConfig\x12\x1a.email.DeleteConfigRequest\x1a\x1b.email.DeleteConfigResponse\x12D\n" +
 	"\vListConfigs\x12\x19.email.ListConfigsRequest\x1a\x1a.email.ListConfigsResponse\x12A\n" +
 	"\n" +
-	"TestConfig\x12\x18.email.TestConfigRequest\x1a\x19.email.TestConfigResponse2O\n" +
+	"TestConfig\x12\x18.email.TestConfigRequest\x1a\x19.email.TestConfigResponse2\xa2\x04\n" +
+	"\x0fTemplateService\x12G\n" +
+	"\x0eCreateTemplate\x12\x1c.email.CreateTemplateRequest\x1a\x17.email.TemplateResponse\x12A\n" +
+	"\vGetTemplate\x12\x19.email.GetTemplateRequest\x1a\x17.email.TemplateResponse\x12G\n" +
+	"\x0eUpdateTemplate\x12\x1c.email.UpdateTemplateRequest\x1a\x17.email.TemplateResponse\x12M\n" +
+	"\x0eDeleteTemplate\x12\x1c.email.DeleteTemplateRequest\x1a\x1d.email.DeleteTemplateResponse\x12J\n" +
+	"\rListTemplates\x12\x1b.email.ListTemplatesRequest\x1a\x1c.email.ListTemplatesResponse\x12M\n" +
+	"\x0eRenderTemplate\x12\x1c.email.RenderTemplateRequest\x1a\x1d.email.RenderTemplateResponse\x12P\n" +
+	"\x12SendTemplatedEmail\x12 .email.SendTemplatedEmailRequest\x1a\x18.email.SendEmailResponse2\xe2\x02\n" +
+	"\x12SuppressionService\x12J\n" +
+	"\x0eAddSuppression\x12\x1c.email.AddSuppressionRequest\x1a\x1a.email.SuppressionResponse\x12V\n" +
+	"\x11RemoveSuppression\x12\x1f.email.RemoveSuppressionRequest\x1a .email.RemoveSuppressionResponse\x12S\n" +
+	"\x10CheckSuppression\x12\x1e.email.CheckSuppressionRequest\x1a\x1f.email.CheckSuppressionResponse\x12S\n" +
+	"\x10ListSuppressions\x12\x1e.email.ListSuppressionsRequest\x1a\x1f.email.ListSuppressionsResponse2O\n" +
 	"\rHealthService\x12>\n" +
 	"\x05Check\x12\x19.email.HealthCheckRequest\x1a\x1a.email.HealthCheckResponseB\x17Z\x15proto/email_client_pbb\x06proto3"
 
@@ -1530,74 +4573,197 @@ func file_proto_email_proto_rawDescGZIP() []byte {
 	return file_proto_email_proto_rawDescData
 }
 
-var file_proto_email_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_email_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_email_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_email_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_proto_email_proto_goTypes = []any{
-	(EmailConfig_Protocol)(0),              // 0: email.EmailConfig.Protocol
-	(HealthCheckResponse_ServingStatus)(0), // 1: email.HealthCheckResponse.ServingStatus
-	(*Attachment)(nil),                     // 2: email.Attachment
-	(*Email)(nil),                          // 3: email.Email
-	(*EmailConfig)(nil),                    // 4: email.EmailConfig
-	(*CreateConfigRequest)(nil),            // 5: email.CreateConfigRequest
-	(*GetConfigRequest)(nil),               // 6: email.GetConfigRequest
-	(*UpdateConfigRequest)(nil),            // 7: email.UpdateConfigRequest
-	(*DeleteConfigRequest)(nil),            // 8: email.DeleteConfigRequest
-	(*DeleteConfigResponse)(nil),           // 9: email.DeleteConfigResponse
-	(*ConfigResponse)(nil),                 // 10: email.ConfigResponse
-	(*ListConfigsRequest)(nil),             // 11: email.ListConfigsRequest
-	(*ListConfigsResponse)(nil),            // 12: email.ListConfigsResponse
-	(*TestConfigRequest)(nil),              // 13: email.TestConfigRequest
-	(*TestConfigResponse)(nil),             // 14: email.TestConfigResponse
-	(*GetSentEmailsRequest)(nil),           // 15: email.GetSentEmailsRequest
-	(*GetSentEmailsResponse)(nil),          // 16: email.GetSentEmailsResponse
-	(*SendEmailRequest)(nil),               // 17: email.SendEmailRequest
-	(*SendEmailResponse)(nil),              // 18: email.SendEmailResponse
-	(*SendEmailsRequest)(nil),              // 19: email.SendEmailsRequest
-	(*SendEmailsResponse)(nil),             // 20: email.SendEmailsResponse
-	(*HealthCheckRequest)(nil),             // 21: email.HealthCheckRequest
-	(*HealthCheckResponse)(nil),            // 22: email.HealthCheckResponse
-	(*timestamppb.Timestamp)(nil),          // 23: google.protobuf.Timestamp
+	(DeliveryState)(0),                     // 0: email.DeliveryState
+	(SuppressionReason)(0),                 // 1: email.SuppressionReason
+	(Attachment_Disposition)(0),            // 2: email.Attachment.Disposition
+	(EmailConfig_Protocol)(0),              // 3: email.EmailConfig.Protocol
+	(TemplateVariable_Type)(0),             // 4: email.TemplateVariable.Type
+	(HealthCheckResponse_ServingStatus)(0), // 5: email.HealthCheckResponse.ServingStatus
+	(*Attachment)(nil),                     // 6: email.Attachment
+	(*Email)(nil),                          // 7: email.Email
+	(*EmailConfig)(nil),                    // 8: email.EmailConfig
+	(*CreateConfigRequest)(nil),            // 9: email.CreateConfigRequest
+	(*GetConfigRequest)(nil),               // 10: email.GetConfigRequest
+	(*UpdateConfigRequest)(nil),            // 11: email.UpdateConfigRequest
+	(*DeleteConfigRequest)(nil),            // 12: email.DeleteConfigRequest
+	(*DeleteConfigResponse)(nil),           // 13: email.DeleteConfigResponse
+	(*ConfigResponse)(nil),                 // 14: email.ConfigResponse
+	(*ListConfigsRequest)(nil),             // 15: email.ListConfigsRequest
+	(*ListConfigsResponse)(nil),            // 16: email.ListConfigsResponse
+	(*TestConfigRequest)(nil),              // 17: email.TestConfigRequest
+	(*TestConfigResponse)(nil),             // 18: email.TestConfigResponse
+	(*GetSentEmailsRequest)(nil),           // 19: email.GetSentEmailsRequest
+	(*GetSentEmailsResponse)(nil),          // 20: email.GetSentEmailsResponse
+	(*SendEmailRequest)(nil),               // 21: email.SendEmailRequest
+	(*SendEmailResponse)(nil),              // 22: email.SendEmailResponse
+	(*ScheduledEmail)(nil),                 // 23: email.ScheduledEmail
+	(*ListScheduledEmailsRequest)(nil),     // 24: email.ListScheduledEmailsRequest
+	(*ListScheduledEmailsResponse)(nil),    // 25: email.ListScheduledEmailsResponse
+	(*CancelScheduledEmailRequest)(nil),    // 26: email.CancelScheduledEmailRequest
+	(*CancelScheduledEmailResponse)(nil),   // 27: email.CancelScheduledEmailResponse
+	(*SendEmailsRequest)(nil),              // 28: email.SendEmailsRequest
+	(*SendEmailsResponse)(nil),             // 29: email.SendEmailsResponse
+	(*GetEmailRequest)(nil),                // 30: email.GetEmailRequest
+	(*GetEmailResponse)(nil),               // 31: email.GetEmailResponse
+	(*GetDeliveryStatusRequest)(nil),       // 32: email.GetDeliveryStatusRequest
+	(*GetDeliveryStatusResponse)(nil),      // 33: email.GetDeliveryStatusResponse
+	(*RecipientDeliveryStatus)(nil),        // 34: email.RecipientDeliveryStatus
+	(*DeliveryEvent)(nil),                  // 35: email.DeliveryEvent
+	(*SendEmailsStreamRequest)(nil),        // 36: email.SendEmailsStreamRequest
+	(*SendEmailEvent)(nil),                 // 37: email.SendEmailEvent
+	(*SendResult)(nil),                     // 38: email.SendResult
+	(*AttachmentMetadata)(nil),             // 39: email.AttachmentMetadata
+	(*UploadAttachmentRequest)(nil),        // 40: email.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),       // 41: email.UploadAttachmentResponse
+	(*TemplateVariable)(nil),               // 42: email.TemplateVariable
+	(*EmailTemplate)(nil),                  // 43: email.EmailTemplate
+	(*CreateTemplateRequest)(nil),          // 44: email.CreateTemplateRequest
+	(*GetTemplateRequest)(nil),             // 45: email.GetTemplateRequest
+	(*UpdateTemplateRequest)(nil),          // 46: email.UpdateTemplateRequest
+	(*DeleteTemplateRequest)(nil),          // 47: email.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),         // 48: email.DeleteTemplateResponse
+	(*TemplateResponse)(nil),               // 49: email.TemplateResponse
+	(*ListTemplatesRequest)(nil),           // 50: email.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),          // 51: email.ListTemplatesResponse
+	(*RenderTemplateRequest)(nil),          // 52: email.RenderTemplateRequest
+	(*RenderTemplateResponse)(nil),         // 53: email.RenderTemplateResponse
+	(*SendTemplatedEmailRequest)(nil),      // 54: email.SendTemplatedEmailRequest
+	(*Suppression)(nil),                    // 55: email.Suppression
+	(*AddSuppressionRequest)(nil),          // 56: email.AddSuppressionRequest
+	(*SuppressionResponse)(nil),            // 57: email.SuppressionResponse
+	(*RemoveSuppressionRequest)(nil),       // 58: email.RemoveSuppressionRequest
+	(*RemoveSuppressionResponse)(nil),      // 59: email.RemoveSuppressionResponse
+	(*CheckSuppressionRequest)(nil),        // 60: email.CheckSuppressionRequest
+	(*CheckSuppressionResponse)(nil),       // 61: email.CheckSuppressionResponse
+	(*ListSuppressionsRequest)(nil),        // 62: email.ListSuppressionsRequest
+	(*ListSuppressionsResponse)(nil),       // 63: email.ListSuppressionsResponse
+	(*HealthCheckRequest)(nil),             // 64: email.HealthCheckRequest
+	(*HealthCheckResponse)(nil),            // 65: email.HealthCheckResponse
+	nil,                                    // 66: email.Email.HeadersEntry
+	(*timestamppb.Timestamp)(nil),          // 67: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                 // 68: google.protobuf.Value
+	(*structpb.Struct)(nil),                // 69: google.protobuf.Struct
 }
 var file_proto_email_proto_depIdxs = []int32{
-	23, // 0: email.Email.sent_at:type_name -> google.protobuf.Timestamp
-	2,  // 1: email.Email.attachments:type_name -> email.Attachment
-	0,  // 2: email.EmailConfig.protocol:type_name -> email.EmailConfig.Protocol
-	23, // 3: email.EmailConfig.created_at:type_name -> google.protobuf.Timestamp
-	23, // 4: email.EmailConfig.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 5: email.CreateConfigRequest.config:type_name -> email.EmailConfig
-	4,  // 6: email.UpdateConfigRequest.config:type_name -> email.EmailConfig
-	4,  // 7: email.ConfigResponse.config:type_name -> email.EmailConfig
-	4,  // 8: email.ListConfigsResponse.configs:type_name -> email.EmailConfig
-	4,  // 9: email.TestConfigRequest.config:type_name -> email.EmailConfig
-	3,  // 10: email.GetSentEmailsResponse.emails:type_name -> email.Email
-	3,  // 11: email.SendEmailRequest.email:type_name -> email.Email
-	3,  // 12: email.SendEmailsRequest.emails:type_name -> email.Email
-	1,  // 13: email.HealthCheckResponse.status:type_name -> email.HealthCheckResponse.ServingStatus
-	15, // 14: email.EmailService.GetSentEmails:input_type -> email.GetSentEmailsRequest
-	17, // 15: email.EmailService.SendEmail:input_type -> email.SendEmailRequest
-	19, // 16: email.EmailService.SendEmails:input_type -> email.SendEmailsRequest
-	5,  // 17: email.EmailConfigService.CreateConfig:input_type -> email.CreateConfigRequest
-	6,  // 18: email.EmailConfigService.GetConfig:input_type -> email.GetConfigRequest
-	7,  // 19: email.EmailConfigService.UpdateConfig:input_type -> email.UpdateConfigRequest
-	8,  // 20: email.EmailConfigService.DeleteConfig:input_type -> email.DeleteConfigRequest
-	11, // 21: email.EmailConfigService.ListConfigs:input_type -> email.ListConfigsRequest
-	13, // 22: email.EmailConfigService.TestConfig:input_type -> email.TestConfigRequest
-	21, // 23: email.HealthService.Check:input_type -> email.HealthCheckRequest
-	16, // 24: email.EmailService.GetSentEmails:output_type -> email.GetSentEmailsResponse
-	18, // 25: email.EmailService.SendEmail:output_type -> email.SendEmailResponse
-	20, // 26: email.EmailService.SendEmails:output_type -> email.SendEmailsResponse
-	10, // 27: email.EmailConfigService.CreateConfig:output_type -> email.ConfigResponse
-	10, // 28: email.EmailConfigService.GetConfig:output_type -> email.ConfigResponse
-	10, // 29: email.EmailConfigService.UpdateConfig:output_type -> email.ConfigResponse
-	9,  // 30: email.EmailConfigService.DeleteConfig:output_type -> email.DeleteConfigResponse
-	12, // 31: email.EmailConfigService.ListConfigs:output_type -> email.ListConfigsResponse
-	14, // 32: email.EmailConfigService.TestConfig:output_type -> email.TestConfigResponse
-	22, // 33: email.HealthService.Check:output_type -> email.HealthCheckResponse
-	24, // [24:34] is the sub-list for method output_type
-	14, // [14:24] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	2,  // 0: email.Attachment.disposition:type_name -> email.Attachment.Disposition
+	67, // 1: email.Email.sent_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: email.Email.attachments:type_name -> email.Attachment
+	66, // 3: email.Email.headers:type_name -> email.Email.HeadersEntry
+	3,  // 4: email.EmailConfig.protocol:type_name -> email.EmailConfig.Protocol
+	67, // 5: email.EmailConfig.created_at:type_name -> google.protobuf.Timestamp
+	67, // 6: email.EmailConfig.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: email.CreateConfigRequest.config:type_name -> email.EmailConfig
+	8,  // 8: email.UpdateConfigRequest.config:type_name -> email.EmailConfig
+	8,  // 9: email.ConfigResponse.config:type_name -> email.EmailConfig
+	8,  // 10: email.ListConfigsResponse.configs:type_name -> email.EmailConfig
+	8,  // 11: email.TestConfigRequest.config:type_name -> email.EmailConfig
+	67, // 12: email.GetSentEmailsRequest.sent_after:type_name -> google.protobuf.Timestamp
+	67, // 13: email.GetSentEmailsRequest.sent_before:type_name -> google.protobuf.Timestamp
+	7,  // 14: email.GetSentEmailsResponse.emails:type_name -> email.Email
+	7,  // 15: email.SendEmailRequest.email:type_name -> email.Email
+	67, // 16: email.SendEmailRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	7,  // 17: email.ScheduledEmail.email:type_name -> email.Email
+	67, // 18: email.ScheduledEmail.scheduled_at:type_name -> google.protobuf.Timestamp
+	67, // 19: email.ScheduledEmail.created_at:type_name -> google.protobuf.Timestamp
+	23, // 20: email.ListScheduledEmailsResponse.emails:type_name -> email.ScheduledEmail
+	7,  // 21: email.SendEmailsRequest.emails:type_name -> email.Email
+	38, // 22: email.SendEmailsResponse.results:type_name -> email.SendResult
+	7,  // 23: email.GetEmailResponse.email:type_name -> email.Email
+	0,  // 24: email.GetEmailResponse.state:type_name -> email.DeliveryState
+	0,  // 25: email.GetDeliveryStatusResponse.state:type_name -> email.DeliveryState
+	34, // 26: email.GetDeliveryStatusResponse.recipients:type_name -> email.RecipientDeliveryStatus
+	35, // 27: email.GetDeliveryStatusResponse.events:type_name -> email.DeliveryEvent
+	67, // 28: email.GetDeliveryStatusResponse.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 29: email.RecipientDeliveryStatus.state:type_name -> email.DeliveryState
+	67, // 30: email.RecipientDeliveryStatus.updated_at:type_name -> google.protobuf.Timestamp
+	67, // 31: email.DeliveryEvent.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 32: email.DeliveryEvent.state:type_name -> email.DeliveryState
+	7,  // 33: email.SendEmailsStreamRequest.email:type_name -> email.Email
+	38, // 34: email.SendEmailEvent.result:type_name -> email.SendResult
+	67, // 35: email.SendEmailEvent.timestamp:type_name -> google.protobuf.Timestamp
+	39, // 36: email.UploadAttachmentRequest.metadata:type_name -> email.AttachmentMetadata
+	4,  // 37: email.TemplateVariable.type:type_name -> email.TemplateVariable.Type
+	68, // 38: email.TemplateVariable.default_value:type_name -> google.protobuf.Value
+	42, // 39: email.EmailTemplate.variables:type_name -> email.TemplateVariable
+	67, // 40: email.EmailTemplate.created_at:type_name -> google.protobuf.Timestamp
+	67, // 41: email.EmailTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 42: email.CreateTemplateRequest.template:type_name -> email.EmailTemplate
+	43, // 43: email.UpdateTemplateRequest.template:type_name -> email.EmailTemplate
+	43, // 44: email.TemplateResponse.template:type_name -> email.EmailTemplate
+	43, // 45: email.ListTemplatesResponse.templates:type_name -> email.EmailTemplate
+	69, // 46: email.RenderTemplateRequest.variables:type_name -> google.protobuf.Struct
+	69, // 47: email.SendTemplatedEmailRequest.variables:type_name -> google.protobuf.Struct
+	1,  // 48: email.Suppression.reason:type_name -> email.SuppressionReason
+	67, // 49: email.Suppression.created_at:type_name -> google.protobuf.Timestamp
+	67, // 50: email.Suppression.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 51: email.AddSuppressionRequest.suppression:type_name -> email.Suppression
+	55, // 52: email.SuppressionResponse.suppression:type_name -> email.Suppression
+	55, // 53: email.CheckSuppressionResponse.suppressions:type_name -> email.Suppression
+	1,  // 54: email.ListSuppressionsRequest.reason:type_name -> email.SuppressionReason
+	55, // 55: email.ListSuppressionsResponse.suppressions:type_name -> email.Suppression
+	5,  // 56: email.HealthCheckResponse.status:type_name -> email.HealthCheckResponse.ServingStatus
+	19, // 57: email.EmailService.GetSentEmails:input_type -> email.GetSentEmailsRequest
+	21, // 58: email.EmailService.SendEmail:input_type -> email.SendEmailRequest
+	28, // 59: email.EmailService.SendEmails:input_type -> email.SendEmailsRequest
+	40, // 60: email.EmailService.UploadAttachment:input_type -> email.UploadAttachmentRequest
+	36, // 61: email.EmailService.SendEmailsStream:input_type -> email.SendEmailsStreamRequest
+	24, // 62: email.EmailService.ListScheduledEmails:input_type -> email.ListScheduledEmailsRequest
+	26, // 63: email.EmailService.CancelScheduledEmail:input_type -> email.CancelScheduledEmailRequest
+	30, // 64: email.EmailService.GetEmail:input_type -> email.GetEmailRequest
+	32, // 65: email.EmailService.GetDeliveryStatus:input_type -> email.GetDeliveryStatusRequest
+	9,  // 66: email.EmailConfigService.CreateConfig:input_type -> email.CreateConfigRequest
+	10, // 67: email.EmailConfigService.GetConfig:input_type -> email.GetConfigRequest
+	11, // 68: email.EmailConfigService.UpdateConfig:input_type -> email.UpdateConfigRequest
+	12, // 69: email.EmailConfigService.DeleteConfig:input_type -> email.DeleteConfigRequest
+	15, // 70: email.EmailConfigService.ListConfigs:input_type -> email.ListConfigsRequest
+	17, // 71: email.EmailConfigService.TestConfig:input_type -> email.TestConfigRequest
+	44, // 72: email.TemplateService.CreateTemplate:input_type -> email.CreateTemplateRequest
+	45, // 73: email.TemplateService.GetTemplate:input_type -> email.GetTemplateRequest
+	46, // 74: email.TemplateService.UpdateTemplate:input_type -> email.UpdateTemplateRequest
+	47, // 75: email.TemplateService.DeleteTemplate:input_type -> email.DeleteTemplateRequest
+	50, // 76: email.TemplateService.ListTemplates:input_type -> email.ListTemplatesRequest
+	52, // 77: email.TemplateService.RenderTemplate:input_type -> email.RenderTemplateRequest
+	54, // 78: email.TemplateService.SendTemplatedEmail:input_type -> email.SendTemplatedEmailRequest
+	56, // 79: email.SuppressionService.AddSuppression:input_type -> email.AddSuppressionRequest
+	58, // 80: email.SuppressionService.RemoveSuppression:input_type -> email.RemoveSuppressionRequest
+	60, // 81: email.SuppressionService.CheckSuppression:input_type -> email.CheckSuppressionRequest
+	62, // 82: email.SuppressionService.ListSuppressions:input_type -> email.ListSuppressionsRequest
+	64, // 83: email.HealthService.Check:input_type -> email.HealthCheckRequest
+	20, // 84: email.EmailService.GetSentEmails:output_type -> email.GetSentEmailsResponse
+	22, // 85: email.EmailService.SendEmail:output_type -> email.SendEmailResponse
+	29, // 86: email.EmailService.SendEmails:output_type -> email.SendEmailsResponse
+	41, // 87: email.EmailService.UploadAttachment:output_type -> email.UploadAttachmentResponse
+	37, // 88: email.EmailService.SendEmailsStream:output_type -> email.SendEmailEvent
+	25, // 89: email.EmailService.ListScheduledEmails:output_type -> email.ListScheduledEmailsResponse
+	27, // 90: email.EmailService.CancelScheduledEmail:output_type -> email.CancelScheduledEmailResponse
+	31, // 91: email.EmailService.GetEmail:output_type -> email.GetEmailResponse
+	33, // 92: email.EmailService.GetDeliveryStatus:output_type -> email.GetDeliveryStatusResponse
+	14, // 93: email.EmailConfigService.CreateConfig:output_type -> email.ConfigResponse
+	14, // 94: email.EmailConfigService.GetConfig:output_type -> email.ConfigResponse
+	14, // 95: email.EmailConfigService.UpdateConfig:output_type -> email.ConfigResponse
+	13, // 96: email.EmailConfigService.DeleteConfig:output_type -> email.DeleteConfigResponse
+	16, // 97: email.EmailConfigService.ListConfigs:output_type -> email.ListConfigsResponse
+	18, // 98: email.EmailConfigService.TestConfig:output_type -> email.TestConfigResponse
+	49, // 99: email.TemplateService.CreateTemplate:output_type -> email.TemplateResponse
+	49, // 100: email.TemplateService.GetTemplate:output_type -> email.TemplateResponse
+	49, // 101: email.TemplateService.UpdateTemplate:output_type -> email.TemplateResponse
+	48, // 102: email.TemplateService.DeleteTemplate:output_type -> email.DeleteTemplateResponse
+	51, // 103: email.TemplateService.ListTemplates:output_type -> email.ListTemplatesResponse
+	53, // 104: email.TemplateService.RenderTemplate:output_type -> email.RenderTemplateResponse
+	22, // 105: email.TemplateService.SendTemplatedEmail:output_type -> email.SendEmailResponse
+	57, // 106: email.SuppressionService.AddSuppression:output_type -> email.SuppressionResponse
+	59, // 107: email.SuppressionService.RemoveSuppression:output_type -> email.RemoveSuppressionResponse
+	61, // 108: email.SuppressionService.CheckSuppression:output_type -> email.CheckSuppressionResponse
+	63, // 109: email.SuppressionService.ListSuppressions:output_type -> email.ListSuppressionsResponse
+	65, // 110: email.HealthService.Check:output_type -> email.HealthCheckResponse
+	84, // [84:111] is the sub-list for method output_type
+	57, // [57:84] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_proto_email_proto_init() }
@@ -1605,15 +4771,20 @@ func file_proto_email_proto_init() {
 	if File_proto_email_proto != nil {
 		return
 	}
+	file_proto_email_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_email_proto_msgTypes[34].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_email_proto_rawDesc), len(file_proto_email_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   21,
+			NumEnums:      6,
+			NumMessages:   61,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   5,
 		},
 		GoTypes:           file_proto_email_proto_goTypes,
 		DependencyIndexes: file_proto_email_proto_depIdxs,
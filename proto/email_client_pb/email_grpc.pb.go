@@ -19,9 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EmailService_GetSentEmails_FullMethodName = "/email.EmailService/GetSentEmails"
-	EmailService_SendEmail_FullMethodName     = "/email.EmailService/SendEmail"
-	EmailService_SendEmails_FullMethodName    = "/email.EmailService/SendEmails"
+	EmailService_GetSentEmails_FullMethodName        = "/email.EmailService/GetSentEmails"
+	EmailService_SendEmail_FullMethodName            = "/email.EmailService/SendEmail"
+	EmailService_SendEmails_FullMethodName           = "/email.EmailService/SendEmails"
+	EmailService_UploadAttachment_FullMethodName     = "/email.EmailService/UploadAttachment"
+	EmailService_SendEmailsStream_FullMethodName     = "/email.EmailService/SendEmailsStream"
+	EmailService_ListScheduledEmails_FullMethodName  = "/email.EmailService/ListScheduledEmails"
+	EmailService_CancelScheduledEmail_FullMethodName = "/email.EmailService/CancelScheduledEmail"
+	EmailService_GetEmail_FullMethodName             = "/email.EmailService/GetEmail"
+	EmailService_GetDeliveryStatus_FullMethodName    = "/email.EmailService/GetDeliveryStatus"
 )
 
 // EmailServiceClient is the client API for EmailService service.
@@ -36,6 +42,18 @@ type EmailServiceClient interface {
 	SendEmail(ctx context.Context, in *SendEmailRequest, opts ...grpc.CallOption) (*SendEmailResponse, error)
 	// SendEmails 批量发送多封邮件
 	SendEmails(ctx context.Context, in *SendEmailsRequest, opts ...grpc.CallOption) (*SendEmailsResponse, error)
+	// UploadAttachment 以客户端流的方式分块上传附件，返回可在发送邮件时引用的附件ID
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error)
+	// 以双向流的方式逐封发送邮件，并在每封邮件处理完成时返回发送事件
+	SendEmailsStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SendEmailsStreamRequest, SendEmailEvent], error)
+	// 获取尚未发送的计划邮件列表
+	ListScheduledEmails(ctx context.Context, in *ListScheduledEmailsRequest, opts ...grpc.CallOption) (*ListScheduledEmailsResponse, error)
+	// 取消尚未发送的计划邮件
+	CancelScheduledEmail(ctx context.Context, in *CancelScheduledEmailRequest, opts ...grpc.CallOption) (*CancelScheduledEmailResponse, error)
+	// GetEmail 按邮件ID获取单封已发送邮件
+	GetEmail(ctx context.Context, in *GetEmailRequest, opts ...grpc.CallOption) (*GetEmailResponse, error)
+	// GetDeliveryStatus 按邮件ID获取投递状态以及SMTP响应时间线
+	GetDeliveryStatus(ctx context.Context, in *GetDeliveryStatusRequest, opts ...grpc.CallOption) (*GetDeliveryStatusResponse, error)
 }
 
 type emailServiceClient struct {
@@ -76,6 +94,72 @@ func (c *emailServiceClient) SendEmails(ctx context.Context, in *SendEmailsReque
 	return out, nil
 }
 
+func (c *emailServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmailService_ServiceDesc.Streams[0], EmailService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, UploadAttachmentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmailService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func (c *emailServiceClient) SendEmailsStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SendEmailsStreamRequest, SendEmailEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmailService_ServiceDesc.Streams[1], EmailService_SendEmailsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SendEmailsStreamRequest, SendEmailEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmailService_SendEmailsStreamClient = grpc.BidiStreamingClient[SendEmailsStreamRequest, SendEmailEvent]
+
+func (c *emailServiceClient) ListScheduledEmails(ctx context.Context, in *ListScheduledEmailsRequest, opts ...grpc.CallOption) (*ListScheduledEmailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledEmailsResponse)
+	err := c.cc.Invoke(ctx, EmailService_ListScheduledEmails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) CancelScheduledEmail(ctx context.Context, in *CancelScheduledEmailRequest, opts ...grpc.CallOption) (*CancelScheduledEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledEmailResponse)
+	err := c.cc.Invoke(ctx, EmailService_CancelScheduledEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) GetEmail(ctx context.Context, in *GetEmailRequest, opts ...grpc.CallOption) (*GetEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmailResponse)
+	err := c.cc.Invoke(ctx, EmailService_GetEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) GetDeliveryStatus(ctx context.Context, in *GetDeliveryStatusRequest, opts ...grpc.CallOption) (*GetDeliveryStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryStatusResponse)
+	err := c.cc.Invoke(ctx, EmailService_GetDeliveryStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmailServiceServer is the server API for EmailService service.
 // All implementations must embed UnimplementedEmailServiceServer
 // for forward compatibility.
@@ -88,6 +172,18 @@ type EmailServiceServer interface {
 	SendEmail(context.Context, *SendEmailRequest) (*SendEmailResponse, error)
 	// SendEmails 批量发送多封邮件
 	SendEmails(context.Context, *SendEmailsRequest) (*SendEmailsResponse, error)
+	// UploadAttachment 以客户端流的方式分块上传附件，返回可在发送邮件时引用的附件ID
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error
+	// 以双向流的方式逐封发送邮件，并在每封邮件处理完成时返回发送事件
+	SendEmailsStream(grpc.BidiStreamingServer[SendEmailsStreamRequest, SendEmailEvent]) error
+	// 获取尚未发送的计划邮件列表
+	ListScheduledEmails(context.Context, *ListScheduledEmailsRequest) (*ListScheduledEmailsResponse, error)
+	// 取消尚未发送的计划邮件
+	CancelScheduledEmail(context.Context, *CancelScheduledEmailRequest) (*CancelScheduledEmailResponse, error)
+	// GetEmail 按邮件ID获取单封已发送邮件
+	GetEmail(context.Context, *GetEmailRequest) (*GetEmailResponse, error)
+	// GetDeliveryStatus 按邮件ID获取投递状态以及SMTP响应时间线
+	GetDeliveryStatus(context.Context, *GetDeliveryStatusRequest) (*GetDeliveryStatusResponse, error)
 	mustEmbedUnimplementedEmailServiceServer()
 }
 
@@ -107,6 +203,24 @@ func (UnimplementedEmailServiceServer) SendEmail(context.Context, *SendEmailRequ
 func (UnimplementedEmailServiceServer) SendEmails(context.Context, *SendEmailsRequest) (*SendEmailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendEmails not implemented")
 }
+func (UnimplementedEmailServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedEmailServiceServer) SendEmailsStream(grpc.BidiStreamingServer[SendEmailsStreamRequest, SendEmailEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SendEmailsStream not implemented")
+}
+func (UnimplementedEmailServiceServer) ListScheduledEmails(context.Context, *ListScheduledEmailsRequest) (*ListScheduledEmailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledEmails not implemented")
+}
+func (UnimplementedEmailServiceServer) CancelScheduledEmail(context.Context, *CancelScheduledEmailRequest) (*CancelScheduledEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledEmail not implemented")
+}
+func (UnimplementedEmailServiceServer) GetEmail(context.Context, *GetEmailRequest) (*GetEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmail not implemented")
+}
+func (UnimplementedEmailServiceServer) GetDeliveryStatus(context.Context, *GetDeliveryStatusRequest) (*GetDeliveryStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryStatus not implemented")
+}
 func (UnimplementedEmailServiceServer) mustEmbedUnimplementedEmailServiceServer() {}
 func (UnimplementedEmailServiceServer) testEmbeddedByValue()                      {}
 
@@ -182,6 +296,92 @@ func _EmailService_SendEmails_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EmailService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EmailServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, UploadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmailService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func _EmailService_SendEmailsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EmailServiceServer).SendEmailsStream(&grpc.GenericServerStream[SendEmailsStreamRequest, SendEmailEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmailService_SendEmailsStreamServer = grpc.BidiStreamingServer[SendEmailsStreamRequest, SendEmailEvent]
+
+func _EmailService_ListScheduledEmails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledEmailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).ListScheduledEmails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailService_ListScheduledEmails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).ListScheduledEmails(ctx, req.(*ListScheduledEmailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_CancelScheduledEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).CancelScheduledEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailService_CancelScheduledEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).CancelScheduledEmail(ctx, req.(*CancelScheduledEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_GetEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailService_GetEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetEmail(ctx, req.(*GetEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_GetDeliveryStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetDeliveryStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailService_GetDeliveryStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetDeliveryStatus(ctx, req.(*GetDeliveryStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EmailService_ServiceDesc is the grpc.ServiceDesc for EmailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,8 +401,36 @@ var EmailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendEmails",
 			Handler:    _EmailService_SendEmails_Handler,
 		},
+		{
+			MethodName: "ListScheduledEmails",
+			Handler:    _EmailService_ListScheduledEmails_Handler,
+		},
+		{
+			MethodName: "CancelScheduledEmail",
+			Handler:    _EmailService_CancelScheduledEmail_Handler,
+		},
+		{
+			MethodName: "GetEmail",
+			Handler:    _EmailService_GetEmail_Handler,
+		},
+		{
+			MethodName: "GetDeliveryStatus",
+			Handler:    _EmailService_GetDeliveryStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _EmailService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SendEmailsStream",
+			Handler:       _EmailService_SendEmailsStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/email.proto",
 }
 
@@ -514,6 +742,582 @@ var EmailConfigService_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "proto/email.proto",
 }
 
+const (
+	TemplateService_CreateTemplate_FullMethodName     = "/email.TemplateService/CreateTemplate"
+	TemplateService_GetTemplate_FullMethodName        = "/email.TemplateService/GetTemplate"
+	TemplateService_UpdateTemplate_FullMethodName     = "/email.TemplateService/UpdateTemplate"
+	TemplateService_DeleteTemplate_FullMethodName     = "/email.TemplateService/DeleteTemplate"
+	TemplateService_ListTemplates_FullMethodName      = "/email.TemplateService/ListTemplates"
+	TemplateService_RenderTemplate_FullMethodName     = "/email.TemplateService/RenderTemplate"
+	TemplateService_SendTemplatedEmail_FullMethodName = "/email.TemplateService/SendTemplatedEmail"
+)
+
+// TemplateServiceClient is the client API for TemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TemplateService 定义邮件模板相关操作的服务
+type TemplateServiceClient interface {
+	// CreateTemplate 创建新的邮件模板
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error)
+	// GetTemplate 根据ID获取指定邮件模板
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error)
+	// UpdateTemplate 更新指定邮件模板
+	UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error)
+	// DeleteTemplate 删除指定邮件模板
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	// ListTemplates 获取邮件模板列表，支持分页
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	// RenderTemplate 使用给定变量渲染模板，用于预览，不发送邮件
+	RenderTemplate(ctx context.Context, in *RenderTemplateRequest, opts ...grpc.CallOption) (*RenderTemplateResponse, error)
+	// SendTemplatedEmail 使用模板渲染并发送邮件
+	SendTemplatedEmail(ctx context.Context, in *SendTemplatedEmailRequest, opts ...grpc.CallOption) (*SendEmailResponse, error)
+}
+
+type templateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTemplateServiceClient(cc grpc.ClientConnInterface) TemplateServiceClient {
+	return &templateServiceClient{cc}
+}
+
+func (c *templateServiceClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*TemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, TemplateService_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) RenderTemplate(ctx context.Context, in *RenderTemplateRequest, opts ...grpc.CallOption) (*RenderTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderTemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_RenderTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) SendTemplatedEmail(ctx context.Context, in *SendTemplatedEmailRequest, opts ...grpc.CallOption) (*SendEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendEmailResponse)
+	err := c.cc.Invoke(ctx, TemplateService_SendTemplatedEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TemplateServiceServer is the server API for TemplateService service.
+// All implementations must embed UnimplementedTemplateServiceServer
+// for forward compatibility.
+//
+// TemplateService 定义邮件模板相关操作的服务
+type TemplateServiceServer interface {
+	// CreateTemplate 创建新的邮件模板
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*TemplateResponse, error)
+	// GetTemplate 根据ID获取指定邮件模板
+	GetTemplate(context.Context, *GetTemplateRequest) (*TemplateResponse, error)
+	// UpdateTemplate 更新指定邮件模板
+	UpdateTemplate(context.Context, *UpdateTemplateRequest) (*TemplateResponse, error)
+	// DeleteTemplate 删除指定邮件模板
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	// ListTemplates 获取邮件模板列表，支持分页
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	// RenderTemplate 使用给定变量渲染模板，用于预览，不发送邮件
+	RenderTemplate(context.Context, *RenderTemplateRequest) (*RenderTemplateResponse, error)
+	// SendTemplatedEmail 使用模板渲染并发送邮件
+	SendTemplatedEmail(context.Context, *SendTemplatedEmailRequest) (*SendEmailResponse, error)
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+// UnimplementedTemplateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTemplateServiceServer struct{}
+
+func (UnimplementedTemplateServiceServer) CreateTemplate(context.Context, *CreateTemplateRequest) (*TemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*TemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) UpdateTemplate(context.Context, *UpdateTemplateRequest) (*TemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedTemplateServiceServer) RenderTemplate(context.Context, *RenderTemplateRequest) (*RenderTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) SendTemplatedEmail(context.Context, *SendTemplatedEmailRequest) (*SendEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTemplatedEmail not implemented")
+}
+func (UnimplementedTemplateServiceServer) mustEmbedUnimplementedTemplateServiceServer() {}
+func (UnimplementedTemplateServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TemplateServiceServer will
+// result in compilation errors.
+type UnsafeTemplateServiceServer interface {
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+func RegisterTemplateServiceServer(s grpc.ServiceRegistrar, srv TemplateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTemplateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TemplateService_ServiceDesc, srv)
+}
+
+func _TemplateService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, req.(*CreateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, req.(*UpdateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_RenderTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).RenderTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_RenderTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).RenderTemplate(ctx, req.(*RenderTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_SendTemplatedEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTemplatedEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).SendTemplatedEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_SendTemplatedEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).SendTemplatedEmail(ctx, req.(*SendTemplatedEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TemplateService_ServiceDesc is the grpc.ServiceDesc for TemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "email.TemplateService",
+	HandlerType: (*TemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _TemplateService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _TemplateService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _TemplateService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _TemplateService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _TemplateService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "RenderTemplate",
+			Handler:    _TemplateService_RenderTemplate_Handler,
+		},
+		{
+			MethodName: "SendTemplatedEmail",
+			Handler:    _TemplateService_SendTemplatedEmail_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/email.proto",
+}
+
+const (
+	SuppressionService_AddSuppression_FullMethodName    = "/email.SuppressionService/AddSuppression"
+	SuppressionService_RemoveSuppression_FullMethodName = "/email.SuppressionService/RemoveSuppression"
+	SuppressionService_CheckSuppression_FullMethodName  = "/email.SuppressionService/CheckSuppression"
+	SuppressionService_ListSuppressions_FullMethodName  = "/email.SuppressionService/ListSuppressions"
+)
+
+// SuppressionServiceClient is the client API for SuppressionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SuppressionService 定义抑制列表相关操作的服务，抑制列表中的地址不会再收到邮件
+type SuppressionServiceClient interface {
+	// AddSuppression 将地址加入抑制列表，地址已存在时更新原因和过期时间
+	AddSuppression(ctx context.Context, in *AddSuppressionRequest, opts ...grpc.CallOption) (*SuppressionResponse, error)
+	// RemoveSuppression 将地址移出抑制列表
+	RemoveSuppression(ctx context.Context, in *RemoveSuppressionRequest, opts ...grpc.CallOption) (*RemoveSuppressionResponse, error)
+	// CheckSuppression 检查一组地址中哪些在抑制列表中
+	CheckSuppression(ctx context.Context, in *CheckSuppressionRequest, opts ...grpc.CallOption) (*CheckSuppressionResponse, error)
+	// ListSuppressions 获取抑制列表，支持分页
+	ListSuppressions(ctx context.Context, in *ListSuppressionsRequest, opts ...grpc.CallOption) (*ListSuppressionsResponse, error)
+}
+
+type suppressionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSuppressionServiceClient(cc grpc.ClientConnInterface) SuppressionServiceClient {
+	return &suppressionServiceClient{cc}
+}
+
+func (c *suppressionServiceClient) AddSuppression(ctx context.Context, in *AddSuppressionRequest, opts ...grpc.CallOption) (*SuppressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuppressionResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_AddSuppression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suppressionServiceClient) RemoveSuppression(ctx context.Context, in *RemoveSuppressionRequest, opts ...grpc.CallOption) (*RemoveSuppressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSuppressionResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_RemoveSuppression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suppressionServiceClient) CheckSuppression(ctx context.Context, in *CheckSuppressionRequest, opts ...grpc.CallOption) (*CheckSuppressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckSuppressionResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_CheckSuppression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suppressionServiceClient) ListSuppressions(ctx context.Context, in *ListSuppressionsRequest, opts ...grpc.CallOption) (*ListSuppressionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuppressionsResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_ListSuppressions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SuppressionServiceServer is the server API for SuppressionService service.
+// All implementations must embed UnimplementedSuppressionServiceServer
+// for forward compatibility.
+//
+// SuppressionService 定义抑制列表相关操作的服务，抑制列表中的地址不会再收到邮件
+type SuppressionServiceServer interface {
+	// AddSuppression 将地址加入抑制列表，地址已存在时更新原因和过期时间
+	AddSuppression(context.Context, *AddSuppressionRequest) (*SuppressionResponse, error)
+	// RemoveSuppression 将地址移出抑制列表
+	RemoveSuppression(context.Context, *RemoveSuppressionRequest) (*RemoveSuppressionResponse, error)
+	// CheckSuppression 检查一组地址中哪些在抑制列表中
+	CheckSuppression(context.Context, *CheckSuppressionRequest) (*CheckSuppressionResponse, error)
+	// ListSuppressions 获取抑制列表，支持分页
+	ListSuppressions(context.Context, *ListSuppressionsRequest) (*ListSuppressionsResponse, error)
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+// UnimplementedSuppressionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSuppressionServiceServer struct{}
+
+func (UnimplementedSuppressionServiceServer) AddSuppression(context.Context, *AddSuppressionRequest) (*SuppressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSuppression not implemented")
+}
+func (UnimplementedSuppressionServiceServer) RemoveSuppression(context.Context, *RemoveSuppressionRequest) (*RemoveSuppressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSuppression not implemented")
+}
+func (UnimplementedSuppressionServiceServer) CheckSuppression(context.Context, *CheckSuppressionRequest) (*CheckSuppressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSuppression not implemented")
+}
+func (UnimplementedSuppressionServiceServer) ListSuppressions(context.Context, *ListSuppressionsRequest) (*ListSuppressionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppressions not implemented")
+}
+func (UnimplementedSuppressionServiceServer) mustEmbedUnimplementedSuppressionServiceServer() {}
+func (UnimplementedSuppressionServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeSuppressionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SuppressionServiceServer will
+// result in compilation errors.
+type UnsafeSuppressionServiceServer interface {
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+func RegisterSuppressionServiceServer(s grpc.ServiceRegistrar, srv SuppressionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSuppressionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SuppressionService_ServiceDesc, srv)
+}
+
+func _SuppressionService_AddSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).AddSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_AddSuppression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).AddSuppression(ctx, req.(*AddSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuppressionService_RemoveSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).RemoveSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_RemoveSuppression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).RemoveSuppression(ctx, req.(*RemoveSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuppressionService_CheckSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).CheckSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_CheckSuppression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).CheckSuppression(ctx, req.(*CheckSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuppressionService_ListSuppressions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppressionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).ListSuppressions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_ListSuppressions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).ListSuppressions(ctx, req.(*ListSuppressionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SuppressionService_ServiceDesc is the grpc.ServiceDesc for SuppressionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SuppressionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "email.SuppressionService",
+	HandlerType: (*SuppressionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddSuppression",
+			Handler:    _SuppressionService_AddSuppression_Handler,
+		},
+		{
+			MethodName: "RemoveSuppression",
+			Handler:    _SuppressionService_RemoveSuppression_Handler,
+		},
+		{
+			MethodName: "CheckSuppression",
+			Handler:    _SuppressionService_CheckSuppression_Handler,
+		},
+		{
+			MethodName: "ListSuppressions",
+			Handler:    _SuppressionService_ListSuppressions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/email.proto",
+}
+
 const (
 	HealthService_Check_FullMethodName = "/email.HealthService/Check"
 )